@@ -0,0 +1,61 @@
+package jenks
+
+import "math/rand"
+
+// ApproximateNaturalBreaks returns approximate natural breaks for datasets too large to classify exactly,
+// computed from a random sample of sampleSize values (always including the minimum and maximum).
+// The sample is chosen deterministically from the seed, so the same arguments always return the same breaks.
+//
+// gvf is the goodness of variance fit of the breaks to the full data, so the loss of accuracy can be judged
+// against the GVF of the sample's own breaks, or of other sample sizes.
+func ApproximateNaturalBreaks(data []float64, nClasses, sampleSize int, seed int64) (breaks []float64, gvf float64) {
+	c := ApproximateNaturalClassification(data, nClasses, sampleSize, seed)
+	return c.Breaks(), c.GVF
+}
+
+// ApproximateNaturalClassification classifies the data using the approximate natural breaks of a sample of it
+// (see ApproximateNaturalBreaks), returning the statistics of each class over the full data.
+func ApproximateNaturalClassification(data []float64, nClasses, sampleSize int, seed int64) *Classification {
+	sample := sampleData(data, sampleSize, seed)
+	breaks := naturalClassification(sortData(sample), nClasses, Options{}).Breaks()
+	return BreaksClassification(breaks, data)
+}
+
+// sampleData returns a random sample of size values from the data (or all of them, if there are no more than size),
+// always including the minimum and maximum values. The sample is chosen by reservoir sampling, using the seed.
+func sampleData(data []float64, size int, seed int64) []float64 {
+	if size < 2 {
+		size = 2
+	}
+	if len(data) <= size {
+		return data
+	}
+
+	minIdx, maxIdx := 0, 0
+	for i, v := range data {
+		if v < data[minIdx] {
+			minIdx = i
+		}
+		if v > data[maxIdx] {
+			maxIdx = i
+		}
+	}
+
+	sample := make([]float64, 0, size)
+	sample = append(sample, data[minIdx], data[maxIdx])
+	reservoir := size - len(sample)
+	random := rand.New(rand.NewSource(seed))
+	seen := 0
+	for i, v := range data {
+		if i == minIdx || i == maxIdx {
+			continue
+		}
+		if seen < reservoir {
+			sample = append(sample, v)
+		} else if r := random.Intn(seen + 1); r < reservoir {
+			sample[2+r] = v
+		}
+		seen++
+	}
+	return sample
+}
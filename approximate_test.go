@@ -0,0 +1,51 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestApproximateNaturalBreaks(t *testing.T) {
+	breaks, gvf := ApproximateNaturalBreaks(largeBenchmarkData, 5, 500, 1)
+	if len(breaks) != 5 {
+		t.Fatalf("ApproximateNaturalBreaks() = %v, want 5 breaks", breaks)
+	}
+	if min, _, _ := dataRange(largeBenchmarkData); breaks[0] != min {
+		t.Errorf("ApproximateNaturalBreaks()[0] = %v, want the minimum %v", breaks[0], min)
+	}
+	if gvf != GVF(breaks, largeBenchmarkData) {
+		t.Errorf("ApproximateNaturalBreaks() gvf = %v, want %v", gvf, GVF(breaks, largeBenchmarkData))
+	}
+	if exact := GVF(NaturalBreaks(largeBenchmarkData, 5), largeBenchmarkData); math.Abs(exact-gvf) > 0.005 {
+		t.Errorf("ApproximateNaturalBreaks() gvf = %v, want close to %v", gvf, exact)
+	}
+
+	again, _ := ApproximateNaturalBreaks(largeBenchmarkData, 5, 500, 1)
+	if !reflect.DeepEqual(breaks, again) {
+		t.Errorf("ApproximateNaturalBreaks() = %v, then %v with the same seed", breaks, again)
+	}
+}
+
+func TestApproximateNaturalBreaks_SmallData(t *testing.T) {
+	// when the sample would include all the data, the breaks are exact
+	breaks, gvf := ApproximateNaturalBreaks(benchmarkData, 5, 1000, 1)
+	want := NaturalClassification(benchmarkData, 5)
+	if !reflect.DeepEqual(breaks, want.Breaks()) || gvf != want.GVF {
+		t.Errorf("ApproximateNaturalBreaks() = %v, %v, want %v, %v", breaks, gvf, want.Breaks(), want.GVF)
+	}
+}
+
+func TestSampleData(t *testing.T) {
+	data := []float64{5, 3, 9, 1, 7, 2, 8, 4, 6, 0, 10}
+	sample := sampleData(data, 4, 7)
+	if len(sample) != 4 {
+		t.Fatalf("sampleData() = %v, want 4 values", sample)
+	}
+	if sample[0] != 0 || sample[1] != 10 {
+		t.Errorf("sampleData() = %v, want the minimum and maximum first", sample)
+	}
+	if !reflect.DeepEqual(data, []float64{5, 3, 9, 1, 7, 2, 8, 4, 6, 0, 10}) {
+		t.Errorf("sampleData() modified the data: %v", data)
+	}
+}
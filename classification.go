@@ -0,0 +1,220 @@
+package jenks
+
+// Class describes a single class within a Classification.
+type Class struct {
+	// Lower is the lower bound of the class - this is the break value returned by NaturalBreaks.
+	Lower float64
+	// Upper is the largest value in the class.
+	Upper float64
+	// Count is the number of data points in the class.
+	Count int
+	// Weight is the total weight of the data points in the class - equal to Count for unweighted data.
+	Weight float64
+	// Sum is the (weighted) sum of the values in the class.
+	Sum float64
+	// Mean is the (weighted) mean of the values in the class.
+	Mean float64
+	// Variance is the (weighted, population) variance of the values in the class.
+	Variance float64
+	// Start and End are the indexes of the class within the sorted data, i.e. the class is Data[Start:End].
+	Start, End int
+}
+
+// Classification is the result of classifying data into classes, along with the statistics of each class.
+type Classification struct {
+	// Data is the sorted data that was classified. It may share its backing array with the caller's data,
+	// so must not be modified.
+	Data []float64
+	// Weights holds the weight of each value in Data, or is nil if the data is unweighted.
+	Weights []float64
+	// Classes contains one entry per class, ordered by ascending lower bound.
+	Classes []Class
+	// GVF is the goodness of variance fit of the classification: 1 - (sum of squared deviations from the class means
+	// / sum of squared deviations from the data mean). It ranges from 0 (no fit) to 1 (perfect fit).
+	GVF float64
+}
+
+// Breaks returns the lower bound of each class - in the same form as returned by NaturalBreaks.
+func (c *Classification) Breaks() []float64 {
+	breaks := make([]float64, len(c.Classes))
+	for i := range c.Classes {
+		breaks[i] = c.Classes[i].Lower
+	}
+	return breaks
+}
+
+// NaturalClassification classifies the data into the best nClasses natural breaks (see NaturalBreaks),
+// returning the statistics of each class.
+func NaturalClassification(data []float64, nClasses int) *Classification {
+	return naturalClassification(sortData(data), nClasses, Options{})
+}
+
+// naturalClassification classifies the sorted data into nClasses natural breaks.
+func naturalClassification(data []float64, nClasses int, opts Options) *Classification {
+	if len(data) == 0 {
+		return &Classification{Data: data}
+	}
+
+	uniq := countUniqueValues(data)
+	if nClasses >= uniq {
+		return newClassification(data, nil, classBoundaries(data, nil, nClasses, nClasses, uniq))
+	}
+
+	if opts.Stable {
+		return newClassification(data, nil, stableBoundaries(data, nil, len(data)-1, nClasses))
+	}
+
+	switch opts.method(len(data), nClasses) {
+	case MethodLinearMemory:
+		return newClassification(data, nil, linearMemoryBoundaries(data, nil, len(data)-1, nClasses, opts.Workers))
+	case MethodFast:
+		return newClassification(data, nil, fastBoundaries(data, nil, len(data)-1, nClasses))
+	}
+
+	lowerClassLimits, _ := getParallelMatrices(data, nil, nClasses, opts.Workers)
+	return newClassification(data, nil, classBoundaries(data, lowerClassLimits, nClasses, nClasses, uniq))
+}
+
+// BestNaturalClassification returns the classification with the fewest classes (up to maxClasses)
+// whose goodness of variance fit is at least minGvf (see BestNaturalBreaks).
+func BestNaturalClassification(data []float64, maxClasses int, minGvf float64) *Classification {
+	data = sortData(data)
+	if len(data) == 0 {
+		return &Classification{Data: data}
+	}
+
+	uniq := countUniqueValues(data)
+	if maxClasses > uniq {
+		maxClasses = uniq
+	}
+
+	lowerClassLimits, _ := getMatrices(data, maxClasses)
+	var bestGvf float64
+	var bestClass = 1
+
+	for nClasses := 2; nClasses <= maxClasses; nClasses++ {
+		gvf := goodnessOfVarianceFit(data, lowerClassLimits, maxClasses, nClasses, uniq)
+
+		if gvf > bestGvf {
+			bestGvf, bestClass = gvf, nClasses
+		}
+
+		if gvf >= minGvf {
+			break
+		}
+	}
+
+	return newClassification(data, nil, classBoundaries(data, lowerClassLimits, maxClasses, bestClass, uniq))
+}
+
+// AllNaturalClassifications returns the natural breaks classification of the data
+// for every number of classes between 2 and maxClasses (see AllNaturalBreaks).
+func AllNaturalClassifications(data []float64, maxClasses int) []*Classification {
+	return naturalClassifications(sortData(data), 2, maxClasses)
+}
+
+// naturalClassifications returns the natural breaks classification of the sorted data for every number of classes
+// between minClasses and maxClasses (capped at the number of unique values), computing the matrices only once.
+func naturalClassifications(data []float64, minClasses, maxClasses int) []*Classification {
+	uniq := countUniqueValues(data)
+	if maxClasses > uniq {
+		maxClasses = uniq
+	}
+
+	lowerClassLimits, _ := getMatrices(data, maxClasses)
+
+	all := []*Classification{}
+	for nClasses := minClasses; nClasses <= maxClasses; nClasses++ {
+		all = append(all, newClassification(data, nil, classBoundaries(data, lowerClassLimits, maxClasses, nClasses, uniq)))
+	}
+	return all
+}
+
+// classBoundaries returns the index into data of the lower bound of each class, in ascending order.
+func classBoundaries(data []float64, lowerClassLimits []int, maxClasses, nClasses, uniq int) []int {
+	boundaries := make([]int, 0, nClasses)
+
+	forEachBreak(data, lowerClassLimits, maxClasses, nClasses, uniq, func(class, boundary int) {
+		boundaries = append(boundaries, boundary)
+	})
+
+	reverseInts(boundaries)
+	return boundaries
+}
+
+// newClassification builds a Classification of the sorted data, where boundaries holds the index
+// of the first value of each class (in ascending order). If weights is nil, the data is unweighted.
+func newClassification(data []float64, weights []float64, boundaries []int) *Classification {
+	c := &Classification{
+		Data:    data,
+		Weights: weights,
+		Classes: make([]Class, len(boundaries)),
+	}
+
+	sdcm := 0.0
+	for i, start := range boundaries {
+		end := len(data)
+		if i+1 < len(boundaries) {
+			end = boundaries[i+1]
+		}
+		class := Class{
+			Count: end - start,
+			Start: start,
+			End:   end,
+		}
+		if start < len(data) {
+			class.Lower, class.Upper = data[start], data[start]
+		}
+		if end > start {
+			class.Upper = data[end-1]
+		}
+
+		var ssd float64
+		if weights == nil {
+			for _, v := range data[start:end] {
+				class.Sum += v
+			}
+			class.Weight = float64(class.Count)
+			class.Mean = mean(data[start:end])
+			ssd = sumOfSquareDeviations(data[start:end])
+		} else {
+			for j := start; j < end; j++ {
+				class.Weight += weights[j]
+				class.Sum += weights[j] * data[j]
+			}
+			class.Mean = weightedMean(data[start:end], weights[start:end])
+			ssd = weightedSumOfSquareDeviations(data[start:end], weights[start:end])
+		}
+		if class.Weight > 0 {
+			class.Variance = ssd / class.Weight
+		}
+		sdcm += ssd
+
+		c.Classes[i] = class
+	}
+
+	if weights == nil {
+		c.GVF = varianceFit(sumOfSquareDeviations(data), sdcm)
+	} else {
+		c.GVF = varianceFit(weightedSumOfSquareDeviations(data, weights), sdcm)
+	}
+	return c
+}
+
+// varianceFit calculates the goodness of variance fit from the sum of squared deviations from the array mean (sdam)
+// and the sum of squared deviations from the class means (sdcm).
+func varianceFit(sdam, sdcm float64) float64 {
+	if sdam == 0 {
+		// all values are identical, so any classification fits perfectly
+		return 1
+	}
+	return (sdam - sdcm) / sdam
+}
+
+func reverseInts(data []int) {
+	for i, j := 0, len(data)-1; i < j; {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
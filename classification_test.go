@@ -0,0 +1,109 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNaturalClassification(t *testing.T) {
+	data := []float64{29, 28, 27, 23, 22, 21, 14, 13, 12, 3, 2, 1}
+	got := NaturalClassification(data, 4)
+
+	want := []Class{
+		{Lower: 1, Upper: 3, Count: 3, Weight: 3, Sum: 6, Mean: 2, Variance: 2.0 / 3, Start: 0, End: 3},
+		{Lower: 12, Upper: 14, Count: 3, Weight: 3, Sum: 39, Mean: 13, Variance: 2.0 / 3, Start: 3, End: 6},
+		{Lower: 21, Upper: 23, Count: 3, Weight: 3, Sum: 66, Mean: 22, Variance: 2.0 / 3, Start: 6, End: 9},
+		{Lower: 27, Upper: 29, Count: 3, Weight: 3, Sum: 84, Mean: 28, Variance: 2.0 / 3, Start: 9, End: 12},
+	}
+	if !reflect.DeepEqual(got.Classes, want) {
+		t.Errorf("NaturalClassification().Classes = %+v, want %+v", got.Classes, want)
+	}
+	if !reflect.DeepEqual(got.Breaks(), []float64{1, 12, 21, 27}) {
+		t.Errorf("NaturalClassification().Breaks() = %v, want %v", got.Breaks(), []float64{1, 12, 21, 27})
+	}
+	if !sort.Float64sAreSorted(got.Data) || len(got.Data) != len(data) {
+		t.Errorf("NaturalClassification().Data = %v, want sorted data", got.Data)
+	}
+	// sdam = 1162.25, sdcm = 4 * 2
+	if wantGvf := (1162.25 - 8) / 1162.25; math.Abs(got.GVF-wantGvf) > 1e-12 {
+		t.Errorf("NaturalClassification().GVF = %v, want %v", got.GVF, wantGvf)
+	}
+}
+
+func TestNaturalClassificationEdgeCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []float64
+		nClasses   int
+		wantCounts []int
+		wantGvf    float64
+	}{
+		{name: "empty data", data: []float64{}, nClasses: 3, wantCounts: []int{}, wantGvf: 0},
+		{name: "one unique value", data: []float64{1, 1, 1}, nClasses: 3, wantCounts: []int{3}, wantGvf: 1},
+		{name: "more classes than unique values", data: []float64{1, 2, 1, 2, 3}, nClasses: 4, wantCounts: []int{2, 2, 1}, wantGvf: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NaturalClassification(tt.data, tt.nClasses)
+			counts := []int{}
+			for _, c := range got.Classes {
+				counts = append(counts, c.Count)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("NaturalClassification() counts = %v, want %v", counts, tt.wantCounts)
+			}
+			if got.GVF != tt.wantGvf {
+				t.Errorf("NaturalClassification().GVF = %v, want %v", got.GVF, tt.wantGvf)
+			}
+		})
+	}
+}
+
+func TestBestNaturalClassificationEmptyData(t *testing.T) {
+	if got := BestNaturalClassification([]float64{}, 3, 0.9); len(got.Classes) != 0 {
+		t.Errorf("BestNaturalClassification() = %+v, want no classes", got.Classes)
+	}
+	if got := BestNaturalBreaks(nil, 3, 0.9); len(got) != 0 {
+		t.Errorf("BestNaturalBreaks() = %v, want no breaks", got)
+	}
+}
+
+func TestClassificationsMatchBreaks(t *testing.T) {
+	for nClasses := 2; nClasses <= 10; nClasses++ {
+		c := NaturalClassification(benchmarkData, nClasses)
+		if !reflect.DeepEqual(c.Breaks(), NaturalBreaks(benchmarkData, nClasses)) {
+			t.Errorf("NaturalClassification(%d).Breaks() = %v, want %v", nClasses, c.Breaks(), NaturalBreaks(benchmarkData, nClasses))
+		}
+		total := 0
+		for i, class := range c.Classes {
+			total += class.Count
+			if class.End-class.Start != class.Count {
+				t.Errorf("class %d: Start=%d End=%d Count=%d", i, class.Start, class.End, class.Count)
+			}
+			if i > 0 && class.Start != c.Classes[i-1].End {
+				t.Errorf("class %d starts at %d, previous class ends at %d", i, class.Start, c.Classes[i-1].End)
+			}
+		}
+		if total != len(benchmarkData) {
+			t.Errorf("NaturalClassification(%d) classified %d values, want %d", nClasses, total, len(benchmarkData))
+		}
+	}
+
+	all := AllNaturalClassifications(benchmarkData, 6)
+	allBreaks := AllNaturalBreaks(benchmarkData, 6)
+	for i := range all {
+		if !reflect.DeepEqual(all[i].Breaks(), allBreaks[i]) {
+			t.Errorf("AllNaturalClassifications()[%d].Breaks() = %v, want %v", i, all[i].Breaks(), allBreaks[i])
+		}
+		if i > 0 && all[i].GVF < all[i-1].GVF {
+			t.Errorf("AllNaturalClassifications()[%d].GVF = %v, less than %v with fewer classes", i, all[i].GVF, all[i-1].GVF)
+		}
+	}
+
+	best := BestNaturalClassification(benchmarkData, 10, 0.9)
+	if !reflect.DeepEqual(best.Breaks(), BestNaturalBreaks(benchmarkData, 10, 0.9)) {
+		t.Errorf("BestNaturalClassification().Breaks() = %v, want %v", best.Breaks(), BestNaturalBreaks(benchmarkData, 10, 0.9))
+	}
+}
@@ -0,0 +1,91 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// Below is the class returned by Classifier.Classify for values below the first break.
+	Below = -1
+	// Above is the class returned by Classifier.Classify for values above the Max of the Classifier.
+	Above = -2
+	// Unclassified is the class returned by Classifier.Classify for NaN values.
+	Unclassified = -3
+)
+
+// Classifier assigns values to the classes defined by a set of breaks, such as those returned by NaturalBreaks.
+type Classifier struct {
+	// Breaks holds the lower bound of each class, in ascending order.
+	Breaks []float64
+	// Max is the upper bound of the last class - usually the maximum value in the data.
+	// Values greater than Max are classified as Above (or in the last class, if Clamp is set).
+	Max float64
+	// UpperInclusive, if set, includes each break in the class below it - i.e. class i holds values in
+	// (Breaks[i], Breaks[i+1]], except that the first break is always included in the first class.
+	// Otherwise (the convention of NaturalBreaks, where each break is the lowest value in its class),
+	// class i holds values in [Breaks[i], Breaks[i+1]). Max is always included in the last class.
+	UpperInclusive bool
+	// Clamp, if set, classifies values below the first break in the first class, and values above Max in the last class,
+	// instead of as Below or Above.
+	Clamp bool
+}
+
+// NewClassifier returns a Classifier for the given breaks, whose last class includes all values up to max.
+// Use math.Inf(1) as max for an open-ended last class.
+func NewClassifier(breaks []float64, max float64) *Classifier {
+	return &Classifier{
+		Breaks: sortData(breaks),
+		Max:    max,
+	}
+}
+
+// Classifier returns a Classifier for the breaks of the classification, whose last class includes all values up to
+// the maximum value in the classified data.
+func (c *Classification) Classifier() *Classifier {
+	max := math.Inf(1)
+	if len(c.Classes) > 0 {
+		max = c.Classes[len(c.Classes)-1].Upper
+	}
+	return NewClassifier(c.Breaks(), max)
+}
+
+// Classify returns the index of the class containing x: between 0 and len(Breaks)-1,
+// or one of Below, Above or Unclassified.
+func (c *Classifier) Classify(x float64) int {
+	if math.IsNaN(x) || len(c.Breaks) == 0 {
+		return Unclassified
+	}
+
+	last := len(c.Breaks) - 1
+	switch {
+	case x < c.Breaks[0]:
+		if c.Clamp {
+			return 0
+		}
+		return Below
+	case x > c.Max:
+		if c.Clamp {
+			return last
+		}
+		return Above
+	case x == c.Breaks[0]:
+		return 0
+	}
+
+	if c.UpperInclusive {
+		// the number of breaks below x
+		return sort.Search(len(c.Breaks), func(i int) bool { return c.Breaks[i] >= x }) - 1
+	}
+	// the number of breaks at or below x
+	return sort.Search(len(c.Breaks), func(i int) bool { return c.Breaks[i] > x }) - 1
+}
+
+// ClassifyAll returns the class of each of the values (see Classify).
+func (c *Classifier) ClassifyAll(values []float64) []int {
+	classes := make([]int, len(values))
+	for i, v := range values {
+		classes[i] = c.Classify(v)
+	}
+	return classes
+}
@@ -0,0 +1,72 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	breaks := []float64{1, 12, 21, 27}
+	values := []float64{0, 1, 3, 11.9, 12, 12.1, 21, 26, 27, 29, 30, math.NaN()}
+
+	tests := []struct {
+		name       string
+		classifier Classifier
+		want       []int
+	}{
+		{name: "lower bounds inclusive",
+			classifier: Classifier{Breaks: breaks, Max: 29},
+			want:       []int{Below, 0, 0, 0, 1, 1, 2, 2, 3, 3, Above, Unclassified}},
+		{name: "upper bounds inclusive",
+			classifier: Classifier{Breaks: breaks, Max: 29, UpperInclusive: true},
+			want:       []int{Below, 0, 0, 0, 0, 1, 1, 2, 2, 3, Above, Unclassified}},
+		{name: "clamped",
+			classifier: Classifier{Breaks: breaks, Max: 29, Clamp: true},
+			want:       []int{0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 3, Unclassified}},
+		{name: "open ended",
+			classifier: Classifier{Breaks: breaks, Max: math.Inf(1)},
+			want:       []int{Below, 0, 0, 0, 1, 1, 2, 2, 3, 3, 3, Unclassified}},
+		{name: "no breaks",
+			classifier: Classifier{Max: 29},
+			want:       []int{Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified, Unclassified}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.classifier.ClassifyAll(values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClassifyAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClassifier(t *testing.T) {
+	breaks := []float64{21, 1, 12}
+	c := NewClassifier(breaks, 29)
+	if !reflect.DeepEqual(c.Breaks, []float64{1, 12, 21}) {
+		t.Errorf("NewClassifier().Breaks = %v, want %v", c.Breaks, []float64{1, 12, 21})
+	}
+	if !reflect.DeepEqual(breaks, []float64{21, 1, 12}) {
+		t.Errorf("NewClassifier() modified the breaks: %v", breaks)
+	}
+	if got := c.Classify(13); got != 1 {
+		t.Errorf("Classify(13) = %v, want 1", got)
+	}
+}
+
+func TestClassification_Classifier(t *testing.T) {
+	classification := NaturalClassification(benchmarkData, 5)
+	classifier := classification.Classifier()
+
+	if classifier.Max != 99.2 {
+		t.Errorf("Classifier().Max = %v, want 99.2", classifier.Max)
+	}
+	// every value in the data is classified in the class that contains it
+	for i, class := range classification.Classes {
+		for _, v := range classification.Data[class.Start:class.End] {
+			if got := classifier.Classify(v); got != i {
+				t.Errorf("Classify(%v) = %v, want %v", v, got, i)
+			}
+		}
+	}
+}
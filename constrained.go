@@ -0,0 +1,79 @@
+package jenks
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInfeasible is returned when no classification of the data satisfies the constraints in the Options.
+var ErrInfeasible = errors.New("jenks: no classification satisfies the constraints")
+
+// constrained returns true if the options constrain the classes.
+func (o Options) constrained() bool {
+	return o.MinClassSize > 1 || o.MinClassWidth > 0
+}
+
+// constrainedBoundaries returns the index of the lowest data point in each class of the optimal classification of
+// the sorted data into at most nClasses classes, where each class contains at least minSize data points, and its
+// values span at least minWidth. Equal values are never split between classes.
+// The largest number of classes (up to nClasses) that satisfies the constraints is used.
+//
+// This is the same dynamic programming approach as getMatrices, except that the variance combinations of
+// infeasible classes are infinite (and the variance of each class is computed from prefix sums).
+func constrainedBoundaries(data []float64, nClasses, minSize int, minWidth float64) ([]int, error) {
+	n := len(data)
+	p := newPrefixSums(data, nil)
+
+	// feasible returns true if data[i:l] can form a class
+	feasible := func(i, l int) bool {
+		return l-i >= minSize && l > i && data[l-1]-data[i] >= minWidth && (i == 0 || data[i-1] != data[i])
+	}
+
+	y := n + 1
+	lowerClassLimits := make([]int, mat2len(nClasses+1, y))
+	prev := make([]float64, y)
+	row := make([]float64, y)
+
+	for l := range prev {
+		prev[l] = math.Inf(+1)
+		if feasible(0, l) {
+			prev[l] = p.variance(0, l)
+		}
+	}
+
+	best := 0
+	if !math.IsInf(prev[n], +1) {
+		best = 1
+	}
+
+	for j := 2; j <= nClasses; j++ {
+		for l := range row {
+			row[l] = math.Inf(+1)
+			for i := j - 1; i <= l-minSize; i++ {
+				if math.IsInf(prev[i], +1) || !feasible(i, l) {
+					continue
+				}
+				if v := prev[i] + p.variance(i, l); v < row[l] {
+					row[l] = v
+					lowerClassLimits[mat2idx(j, l, y)] = i
+				}
+			}
+		}
+		if !math.IsInf(row[n], +1) {
+			best = j
+		}
+		prev, row = row, prev
+	}
+
+	if best == 0 {
+		return nil, ErrInfeasible
+	}
+
+	boundaries := make([]int, best)
+	k := n
+	for i := best; i > 1; i-- {
+		k = lowerClassLimits[mat2idx(i, k, y)]
+		boundaries[i-1] = k
+	}
+	return boundaries, nil
+}
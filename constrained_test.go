@@ -0,0 +1,117 @@
+package jenks
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestConstrainedNaturalBreaks(t *testing.T) {
+	withOutlier := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 100}
+
+	tests := []struct {
+		name     string
+		data     []float64
+		nClasses int
+		opts     Options
+		want     []float64
+		wantErr  error
+	}{
+		{name: "minimum class size",
+			data: withOutlier, nClasses: 3, opts: Options{MinClassSize: 3},
+			want: []float64{1, 4, 8}},
+		{name: "fewer classes than requested",
+			data: withOutlier, nClasses: 3, opts: Options{MinClassSize: 4},
+			want: []float64{1, 7}},
+		{name: "repeated values are not split",
+			data: []float64{1, 1, 1, 1, 2, 2, 2, 9, 9}, nClasses: 3, opts: Options{MinClassSize: 2},
+			want: []float64{1, 2, 9}},
+		{name: "repeated values are not split to satisfy the size",
+			data: []float64{1, 1, 1, 1, 2, 9, 9}, nClasses: 3, opts: Options{MinClassSize: 2},
+			want: []float64{1, 9}},
+		{name: "minimum class width",
+			data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}, nClasses: 4, opts: Options{MinClassWidth: 3},
+			want: []float64{1, 13, 22}},
+		{name: "infeasible size",
+			data: withOutlier, nClasses: 3, opts: Options{MinClassSize: 11},
+			wantErr: ErrInfeasible},
+		{name: "infeasible width",
+			data: withOutlier, nClasses: 3, opts: Options{MinClassWidth: 100},
+			wantErr: ErrInfeasible},
+		{name: "NaN width",
+			data: withOutlier, nClasses: 3, opts: Options{MinClassWidth: math.NaN()},
+			wantErr: ErrNaN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NaturalBreaksWithOptions(tt.data, tt.nClasses, tt.opts)
+			if err != tt.wantErr {
+				t.Fatalf("NaturalBreaksWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NaturalBreaksWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstrainedNaturalBreaksIsOptimal(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	for trial := 0; trial < 20; trial++ {
+		data := make([]float64, 16)
+		for i := range data {
+			data[i] = float64(random.Intn(40))
+		}
+		data = sortData(data)
+		opts := Options{MinClassSize: 1 + random.Intn(4), MinClassWidth: float64(random.Intn(4))}
+		nClasses := 2 + random.Intn(3)
+
+		got, err := NaturalClassificationWithOptions(data, nClasses, opts)
+		want := bruteForceConstrained(data, nClasses, opts)
+		if want == nil {
+			if err != ErrInfeasible {
+				t.Errorf("%v %+v: NaturalClassificationWithOptions() error = %v, want %v", data, opts, err, ErrInfeasible)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v %+v: NaturalClassificationWithOptions() error = %v", data, opts, err)
+		}
+		if len(got.Classes) != len(want.Classes) || math.Abs(got.GVF-want.GVF) > 1e-9 {
+			t.Errorf("%v %+v: NaturalClassificationWithOptions(%d) = %v (GVF %v), want %v (GVF %v)", data, opts, nClasses, got.Breaks(), got.GVF, want.Breaks(), want.GVF)
+		}
+		for _, c := range got.Classes {
+			if c.Count < opts.MinClassSize || c.Upper-c.Lower < opts.MinClassWidth {
+				t.Errorf("%v %+v: class %+v doesn't satisfy the constraints", data, opts, c)
+			}
+		}
+	}
+}
+
+// bruteForceConstrained returns the best classification of the sorted data into the most classes (up to nClasses)
+// that satisfy the constraints, or nil if there are none.
+func bruteForceConstrained(data []float64, nClasses int, opts Options) *Classification {
+	var best *Classification
+	var try func(boundaries []int)
+	try = func(boundaries []int) {
+		c := newClassification(data, nil, boundaries)
+		ok := true
+		for _, class := range c.Classes {
+			ok = ok && class.Count >= opts.MinClassSize && class.Upper-class.Lower >= opts.MinClassWidth
+		}
+		if ok && (best == nil || len(c.Classes) > len(best.Classes) || (len(c.Classes) == len(best.Classes) && c.GVF > best.GVF)) {
+			best = c
+		}
+		if len(boundaries) == nClasses {
+			return
+		}
+		for b := boundaries[len(boundaries)-1] + 1; b < len(data); b++ {
+			if data[b] != data[b-1] {
+				try(append(boundaries[:len(boundaries):len(boundaries)], b))
+			}
+		}
+	}
+	try([]int{0})
+	return best
+}
@@ -0,0 +1,109 @@
+package jenks
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrEmptyData is returned when there is no data to classify.
+	ErrEmptyData = errors.New("jenks: empty data")
+	// ErrNaN is returned when the data (or breaks) contain a NaN value.
+	ErrNaN = errors.New("jenks: data contains NaN")
+	// ErrInf is returned when the data (or breaks) contain an infinite value.
+	ErrInf = errors.New("jenks: data contains an infinite value")
+	// ErrInvalidClassCount is returned when the requested number of classes is less than 1.
+	ErrInvalidClassCount = errors.New("jenks: number of classes must be at least 1")
+	// ErrInvalidWeights is returned when the weights don't match the data in length, or are negative, NaN or infinite.
+	ErrInvalidWeights = errors.New("jenks: weights must be finite, non-negative and one per data point")
+	// ErrTooLarge is returned when the matrices required to compute the breaks would exceed MaxMatrixSize.
+	ErrTooLarge = errors.New("jenks: data too large to classify")
+	// ErrTooManyClasses is returned when a qualitative palette has fewer colours than there are classes.
+	ErrTooManyClasses = errors.New("jenks: too many classes for the palette")
+)
+
+// MaxMatrixSize is the largest number of cells ((len(data)+1) * (nClasses+1)) that will be allocated for each of
+// the matrices used to compute natural breaks. Above this, NaturalBreaks switches to MethodLinearMemory,
+// while the error-returning functions that need the full matrices (such as AllNaturalBreaksE) return ErrTooLarge.
+var MaxMatrixSize = 1 << 24
+
+// NaturalBreaksE is like NaturalBreaks, but returns an error instead of panicking or returning meaningless breaks
+// when the data is empty, contains NaN or infinite values, or nClasses is less than 1.
+func NaturalBreaksE(data []float64, nClasses int) ([]float64, error) {
+	return NaturalBreaksWithOptions(data, nClasses, Options{})
+}
+
+// BestNaturalBreaksE is like BestNaturalBreaks, but returns an error instead of panicking or returning meaningless
+// breaks when the data is empty, contains NaN or infinite values, maxClasses is less than 1, or the data is too large.
+func BestNaturalBreaksE(data []float64, maxClasses int, minGvf float64) ([]float64, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if maxClasses < 1 {
+		return nil, ErrInvalidClassCount
+	}
+	if err := validateSize(data, maxClasses); err != nil {
+		return nil, err
+	}
+	return BestNaturalBreaks(data, maxClasses, minGvf), nil
+}
+
+// AllNaturalBreaksE is like AllNaturalBreaks, but returns an error instead of panicking or returning meaningless
+// breaks when the data is empty, contains NaN or infinite values, maxClasses is less than 1, or the data is too large.
+func AllNaturalBreaksE(data []float64, maxClasses int) ([][]float64, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if maxClasses < 1 {
+		return nil, ErrInvalidClassCount
+	}
+	if err := validateSize(data, maxClasses); err != nil {
+		return nil, err
+	}
+	return AllNaturalBreaks(data, maxClasses), nil
+}
+
+// RoundE is like Round, but returns an error instead of panicking or returning meaningless values
+// when the data is empty, or the data or breaks contain NaN or infinite values.
+func RoundE(breaks []float64, data []float64) ([]float64, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if err := validateValues(breaks); err != nil {
+		return nil, err
+	}
+	return Round(breaks, data), nil
+}
+
+// validateData checks that the data is non-empty and contains only finite values.
+func validateData(data []float64) error {
+	if len(data) == 0 {
+		return ErrEmptyData
+	}
+	return validateValues(data)
+}
+
+// validateValues checks that the values are all finite.
+func validateValues(values []float64) error {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return ErrNaN
+		}
+		if math.IsInf(v, 0) {
+			return ErrInf
+		}
+	}
+	return nil
+}
+
+// validateSize checks that the matrices needed to classify the data into nClasses would not exceed MaxMatrixSize.
+// The matrices are only computed for as many classes as there are unique values, so nClasses is capped accordingly.
+func validateSize(data []float64, nClasses int) error {
+	if uniq := countUniqueValues(sortData(data)); nClasses > uniq {
+		nClasses = uniq
+	}
+	if !matrixFits(len(data), nClasses) {
+		return ErrTooLarge
+	}
+	return nil
+}
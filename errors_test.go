@@ -0,0 +1,112 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNaturalBreaksE(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		nClasses int
+		want     []float64
+		wantErr  error
+	}{
+		{name: "valid", data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}, nClasses: 3, want: []float64{1, 12, 21}},
+		{name: "nil data", data: nil, nClasses: 3, wantErr: ErrEmptyData},
+		{name: "empty data", data: []float64{}, nClasses: 3, wantErr: ErrEmptyData},
+		{name: "NaN", data: []float64{1, 2, math.NaN(), 4}, nClasses: 2, wantErr: ErrNaN},
+		{name: "+Inf", data: []float64{1, 2, math.Inf(1), 4}, nClasses: 2, wantErr: ErrInf},
+		{name: "-Inf", data: []float64{math.Inf(-1), 2, 3, 4}, nClasses: 2, wantErr: ErrInf},
+		{name: "zero classes", data: []float64{1, 2, 3}, nClasses: 0, wantErr: ErrInvalidClassCount},
+		{name: "negative classes", data: []float64{1, 2, 3}, nClasses: -1, wantErr: ErrInvalidClassCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NaturalBreaksE(tt.data, tt.nClasses)
+			if err != tt.wantErr {
+				t.Fatalf("NaturalBreaksE() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NaturalBreaksE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestAndAllNaturalBreaksE(t *testing.T) {
+	data := []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}
+
+	if got, err := BestNaturalBreaksE(data, 4, 0.95); err != nil || !reflect.DeepEqual(got, []float64{1, 12, 21}) {
+		t.Errorf("BestNaturalBreaksE() = %v, %v, want %v, nil", got, err, []float64{1, 12, 21})
+	}
+	if _, err := BestNaturalBreaksE([]float64{math.NaN()}, 4, 0.95); err != ErrNaN {
+		t.Errorf("BestNaturalBreaksE() error = %v, want %v", err, ErrNaN)
+	}
+	if _, err := BestNaturalBreaksE(data, 0, 0.95); err != ErrInvalidClassCount {
+		t.Errorf("BestNaturalBreaksE() error = %v, want %v", err, ErrInvalidClassCount)
+	}
+
+	if got, err := AllNaturalBreaksE(data, 3); err != nil || !reflect.DeepEqual(got, [][]float64{{1, 21}, {1, 12, 21}}) {
+		t.Errorf("AllNaturalBreaksE() = %v, %v, want %v, nil", got, err, [][]float64{{1, 21}, {1, 12, 21}})
+	}
+	if _, err := AllNaturalBreaksE(nil, 3); err != ErrEmptyData {
+		t.Errorf("AllNaturalBreaksE() error = %v, want %v", err, ErrEmptyData)
+	}
+}
+
+func TestErrTooLarge(t *testing.T) {
+	defer func(size int) { MaxMatrixSize = size }(MaxMatrixSize)
+	MaxMatrixSize = 100
+
+	// 13 * 4 cells fit, 13 * 11 do not - but only as many classes as unique values are ever computed
+	data := []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}
+	if _, err := NaturalBreaksE(data, 3); err != nil {
+		t.Errorf("NaturalBreaksE() error = %v, want nil", err)
+	}
+	// NaturalBreaksE doesn't need the full matrices, so switches to the linear memory method instead
+	if got, err := NaturalBreaksE(data, 10); err != nil || !reflect.DeepEqual(got, NaturalBreaks(data, 10)) {
+		t.Errorf("NaturalBreaksE() = %v, %v, want %v, nil", got, err, NaturalBreaks(data, 10))
+	}
+	if _, err := NaturalBreaksWithOptions(data, 10, Options{Method: MethodMatrix}); err != ErrTooLarge {
+		t.Errorf("NaturalBreaksWithOptions(MethodMatrix) error = %v, want %v", err, ErrTooLarge)
+	}
+	if _, err := AllNaturalBreaksE([]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2}, 10); err != nil {
+		t.Errorf("AllNaturalBreaksE() error = %v, want nil", err)
+	}
+	if _, err := BestNaturalBreaksE(data, 10, 0.95); err != ErrTooLarge {
+		t.Errorf("BestNaturalBreaksE() error = %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestRoundE(t *testing.T) {
+	data := []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 21.1, 22.1, 23.1, 27.1, 28.1, 29.1}
+	tests := []struct {
+		name    string
+		breaks  []float64
+		data    []float64
+		want    []float64
+		wantErr error
+	}{
+		{name: "valid", breaks: []float64{1.1, 12.1, 21.1, 27.1}, data: data, want: []float64{0, 10, 20, 27}},
+		{name: "single break", breaks: []float64{1.1}, data: data, want: []float64{0}},
+		{name: "no breaks", breaks: []float64{}, data: data, want: []float64{}},
+		{name: "empty data", breaks: []float64{1.1}, data: nil, wantErr: ErrEmptyData},
+		{name: "NaN data", breaks: []float64{1.1}, data: []float64{1.1, math.NaN()}, wantErr: ErrNaN},
+		{name: "NaN break", breaks: []float64{math.NaN()}, data: data, wantErr: ErrNaN},
+		{name: "infinite break", breaks: []float64{1.1, math.Inf(1)}, data: data, wantErr: ErrInf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundE(tt.breaks, tt.data)
+			if err != tt.wantErr {
+				t.Fatalf("RoundE() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RoundE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
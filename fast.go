@@ -0,0 +1,104 @@
+package jenks
+
+import "math"
+
+// fastBoundaries computes the class boundaries that forEachBreak derives from getMatrices(data, nClasses),
+// in O(nClasses * n * log(n)) time rather than O(nClasses * n^2).
+//
+// Each row of the variance combinations matrix is computed using the divide and conquer optimisation:
+// the optimal lower class limit never decreases as the upper end of the class increases
+// (as exploited by Ckmeans.1d.dp), so the search for each cell can be narrowed by the cells either side of it.
+// The variance of each candidate class is computed in constant time from prefix sums of the (shifted) data.
+func fastBoundaries(data []float64, weights []float64, last, nClasses int) []int {
+	n := len(data)
+	p := newPrefixSums(data, weights)
+
+	prev := make([]float64, n+1)
+	row := make([]float64, n+1)
+	for l := 2; l <= n; l++ {
+		prev[l] = p.variance(0, l)
+	}
+
+	// lowerClassLimits holds the optimal lower class limit of each cell for 2 or more classes;
+	// int32 halves the memory needed for large datasets, which are the reason to use this method.
+	y := n + 1
+	lowerClassLimits := make([]int32, mat2len(nClasses+1, y))
+
+	var solve func(lo, hi, optLo, optHi int, limits []int32)
+	solve = func(lo, hi, optLo, optHi int, limits []int32) {
+		if lo > hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		best, limit := math.Inf(+1), 0
+		for lowerClassLimit := optLo; lowerClassLimit <= mid && lowerClassLimit <= optHi; lowerClassLimit++ {
+			// choose the smallest lower class limit on ties, as getMatrices does
+			if v := prev[lowerClassLimit-1] + p.variance(lowerClassLimit-1, mid); v < best {
+				best, limit = v, lowerClassLimit
+			}
+		}
+		row[mid] = best
+		limits[mid] = int32(limit)
+
+		solve(lo, mid-1, optLo, limit, limits)
+		solve(mid+1, hi, limit, optHi, limits)
+	}
+
+	for j := 2; j <= nClasses; j++ {
+		limits := lowerClassLimits[mat2idx(j, 0, y) : mat2idx(j, 0, y)+y]
+		row[0], row[1] = 0, 0
+		limits[1] = 1
+		solve(2, n, 2, n, limits)
+		prev, row = row, prev
+	}
+
+	boundaries := make([]int, nClasses)
+	k := last
+	for i := nClasses; i > 1; i-- {
+		k = int(lowerClassLimits[mat2idx(i, k, y)]) - 1
+		boundaries[i-1] = k
+	}
+	boundaries[0] = 0
+	return boundaries
+}
+
+// prefixSums holds the cumulative weight, sum and sum of squares of the data, so that the variance of any
+// range of data can be computed in constant time. The data is shifted by its median value to limit the
+// loss of precision when subtracting large sums.
+type prefixSums struct {
+	weight, sum, sumSquares []float64
+}
+
+func newPrefixSums(data []float64, weights []float64) prefixSums {
+	p := prefixSums{
+		weight:     make([]float64, len(data)+1),
+		sum:        make([]float64, len(data)+1),
+		sumSquares: make([]float64, len(data)+1),
+	}
+	if len(data) == 0 {
+		return p
+	}
+
+	shift := data[len(data)/2]
+	for i, v := range data {
+		wt := 1.0
+		if weights != nil {
+			wt = weights[i]
+		}
+		v -= shift
+		p.weight[i+1] = p.weight[i] + wt
+		p.sum[i+1] = p.sum[i] + wt*v
+		p.sumSquares[i+1] = p.sumSquares[i] + wt*v*v
+	}
+	return p
+}
+
+// variance returns the (weighted) sum of squared deviations from the mean of data[i:j].
+func (p prefixSums) variance(i, j int) float64 {
+	w := p.weight[j] - p.weight[i]
+	if w == 0 {
+		return 0
+	}
+	sum := p.sum[j] - p.sum[i]
+	return (p.sumSquares[j] - p.sumSquares[i]) - (sum*sum)/w
+}
@@ -0,0 +1,99 @@
+package jenks
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestFastMatchesMatrix(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	randomData := make([]float64, 1000)
+	for i := range randomData {
+		randomData[i] = random.NormFloat64() * 100
+	}
+	randomIntegers := make([]float64, 1000)
+	for i := range randomIntegers {
+		randomIntegers[i] = float64(random.Intn(50))
+	}
+
+	// the cases from TestNaturalBreaks
+	cases := []struct {
+		data     []float64
+		nClasses int
+	}{
+		{nClasses: 2, data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}},
+		{nClasses: 3, data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}},
+		{nClasses: 4, data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}},
+		{nClasses: 4, data: []float64{1.1, 1.1, 1.1, 1.3, 1.3, 1.3, 1.2, 1.2, 1.2}},
+		{nClasses: 4, data: []float64{1, 1, 1, 1}},
+		{nClasses: 4, data: []float64{1, 2}},
+		{nClasses: 4, data: benchmarkData},
+		{nClasses: 3, data: []float64{5, 8, 9, 12, 15}},
+		{nClasses: 5, data: []float64{5.534023222200083e+19, 5.534023222200094e+19, 5.5340232222001e+19, 5.5340232222001e+19, 5.534023222200112e+19, 5.534023222200113e+19}},
+	}
+	for _, c := range cases {
+		want := NaturalBreaks(c.data, c.nClasses)
+		got, err := NaturalBreaksWithOptions(c.data, c.nClasses, Options{Method: MethodFast})
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("NaturalBreaksWithOptions(%d, MethodFast) = %v, %v, want %v", c.nClasses, got, err, want)
+		}
+	}
+
+	// data without exact ties between candidate classes, where the choice would depend on rounding errors
+	tests := []struct {
+		name string
+		data []float64
+	}{
+		{name: "benchmark data", data: benchmarkData},
+		{name: "random", data: randomData},
+		{name: "random integers", data: randomIntegers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for nClasses := 1; nClasses <= 12; nClasses++ {
+				want := NaturalBreaks(tt.data, nClasses)
+				got, err := NaturalBreaksWithOptions(tt.data, nClasses, Options{Method: MethodFast})
+				if err != nil || !reflect.DeepEqual(got, want) {
+					t.Errorf("NaturalBreaksWithOptions(%d, MethodFast) = %v, %v, want %v", nClasses, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFastMatchesMatrixWeighted(t *testing.T) {
+	values := []float64{28.9, 40.7, 52.2, 55.8, 60.1, 64.7, 67.0, 71.2, 73.0, 76.3, 79.2, 83.8, 87.7, 99.2}
+	weights := []float64{1, 1, 2, 2, 2, 2, 2, 4, 4, 3, 3, 2, 2, 2.5}
+
+	for nClasses := 2; nClasses < len(values); nClasses++ {
+		lowerClassLimits, _ := getWeightedMatrices(values, weights, nClasses)
+		want := make([]int, nClasses)
+		forEachLimit(lowerClassLimits, len(values), nClasses, nClasses, func(class, boundary int) {
+			want[class-1] = boundary
+		})
+		if got := fastBoundaries(values, weights, len(values), nClasses); !reflect.DeepEqual(got, want) {
+			t.Errorf("fastBoundaries(%d) = %v, want %v", nClasses, got, want)
+		}
+	}
+}
+
+func TestFastScales(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large dataset in short mode")
+	}
+	random := rand.New(rand.NewSource(7))
+	data := make([]float64, 200000)
+	for i := range data {
+		data[i] = random.ExpFloat64() * 1000
+	}
+	breaks, err := NaturalBreaksWithOptions(data, 8, Options{Method: MethodFast})
+	if err != nil || len(breaks) != 8 {
+		t.Fatalf("NaturalBreaksWithOptions(MethodFast) = %v, %v", breaks, err)
+	}
+	for i := 1; i < len(breaks); i++ {
+		if breaks[i] <= breaks[i-1] {
+			t.Errorf("NaturalBreaksWithOptions(MethodFast) = %v, want ascending breaks", breaks)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package jenks
+
+import "sort"
+
+// Number is the set of numeric types accepted by the generic functions, such as NaturalBreaksOf.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// The generic functions classify the data as float64, so integers beyond 2^53 in magnitude may not be
+// distinguished from their neighbours. The breaks are taken from the caller's data, so are returned exactly.
+
+// NaturalBreaksOf is like NaturalBreaks, for data of any numeric type.
+func NaturalBreaksOf[T Number](data []T, nClasses int) []T {
+	sorted, floats := sortNumbers(data)
+	return breaksOf(sorted, naturalClassification(floats, nClasses, Options{}))
+}
+
+// AllNaturalBreaksOf is like AllNaturalBreaks, for data of any numeric type.
+func AllNaturalBreaksOf[T Number](data []T, maxClasses int) [][]T {
+	sorted, floats := sortNumbers(data)
+	allBreaks := [][]T{}
+	for _, c := range naturalClassifications(floats, 2, maxClasses) {
+		allBreaks = append(allBreaks, breaksOf(sorted, c))
+	}
+	return allBreaks
+}
+
+// BestNaturalBreaksOf is like BestNaturalBreaks, for data of any numeric type.
+func BestNaturalBreaksOf[T Number](data []T, maxClasses int, minGvf float64) []T {
+	sorted, floats := sortNumbers(data)
+	return breaksOf(sorted, BestNaturalClassification(floats, maxClasses, minGvf))
+}
+
+// RoundOf is like Round, for data of any numeric type. A break is left unrounded if its rounded value
+// can't be represented in the type without changing the membership of a class.
+func RoundOf[T Number](breaks []T, data []T) []T {
+	sorted, floats := sortNumbers(data)
+	rounded := Round(toFloat64s(breaks), floats)
+
+	result := make([]T, len(breaks))
+	for i, r := range rounded {
+		result[i] = T(r)
+		if firstAtLeast(sorted, result[i]) != firstAtLeast(sorted, breaks[i]) {
+			result[i] = breaks[i]
+		}
+	}
+	return result
+}
+
+// sortNumbers returns a sorted copy of the data, along with the same values as float64.
+func sortNumbers[T Number](data []T) ([]T, []float64) {
+	sorted := append(make([]T, 0, len(data)), data...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted, toFloat64s(sorted)
+}
+
+func toFloat64s[T Number](data []T) []float64 {
+	floats := make([]float64, len(data))
+	for i, v := range data {
+		floats[i] = float64(v)
+	}
+	return floats
+}
+
+// breaksOf returns the lowest value in each class of the classification of the sorted data.
+func breaksOf[T Number](sorted []T, c *Classification) []T {
+	breaks := make([]T, len(c.Classes))
+	for i, class := range c.Classes {
+		breaks[i] = sorted[class.Start]
+	}
+	return breaks
+}
+
+// firstAtLeast returns the index of the first value in the sorted data that is at least x.
+func firstAtLeast[T Number](sorted []T, x T) int {
+	return sort.Search(len(sorted), func(i int) bool { return sorted[i] >= x })
+}
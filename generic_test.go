@@ -0,0 +1,68 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNaturalBreaksOf(t *testing.T) {
+	ints := []int64{}
+	float32s := []float32{}
+	for _, v := range benchmarkData {
+		ints = append(ints, int64(v*10))
+		float32s = append(float32s, float32(v))
+	}
+
+	wantInts := []int64{}
+	for _, b := range NaturalBreaks(toFloat64s(ints), 5) {
+		wantInts = append(wantInts, int64(b))
+	}
+	if got := NaturalBreaksOf(ints, 5); !reflect.DeepEqual(got, wantInts) {
+		t.Errorf("NaturalBreaksOf(int64) = %v, want %v", got, wantInts)
+	}
+
+	if got := NaturalBreaksOf(float32s, 5); !reflect.DeepEqual(toFloat64s(got), NaturalBreaks(toFloat64s(float32s), 5)) {
+		t.Errorf("NaturalBreaksOf(float32) = %v, want %v", got, NaturalBreaks(toFloat64s(float32s), 5))
+	}
+
+	if got := NaturalBreaksOf([]uint32{7, 1, 2, 8, 1, 9}, 2); !reflect.DeepEqual(got, []uint32{1, 7}) {
+		t.Errorf("NaturalBreaksOf(uint32) = %v, want %v", got, []uint32{1, 7})
+	}
+}
+
+func TestAllNaturalBreaksOf(t *testing.T) {
+	data := []uint32{7, 1, 2, 8, 1, 9, 4}
+	want := [][]uint32{}
+	for _, breaks := range AllNaturalBreaks(toFloat64s(data), 4) {
+		want = append(want, []uint32{})
+		for _, b := range breaks {
+			want[len(want)-1] = append(want[len(want)-1], uint32(b))
+		}
+	}
+	if got := AllNaturalBreaksOf(data, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllNaturalBreaksOf() = %v, want %v", got, want)
+	}
+}
+
+func TestBestNaturalBreaksOf(t *testing.T) {
+	data := []int{1, 2, 4, 5, 7, 9, 10, 20}
+	want := []int{}
+	for _, b := range BestNaturalBreaks(toFloat64s(data), 5, 0.9) {
+		want = append(want, int(b))
+	}
+	if got := BestNaturalBreaksOf(data, 5, 0.9); !reflect.DeepEqual(got, want) {
+		t.Errorf("BestNaturalBreaksOf() = %v, want %v", got, want)
+	}
+}
+
+func TestRoundOf(t *testing.T) {
+	data := []int{1111, 1234, 1299, 2345, 2399, 5678}
+	if got := RoundOf([]int{1111, 2345, 5678}, data); !reflect.DeepEqual(got, []int{0, 2000, 5000}) {
+		t.Errorf("RoundOf(int) = %v, want %v", got, []int{0, 2000, 5000})
+	}
+
+	float32s := []float32{1.25, 1.5, 3.75, 4.5}
+	if got := RoundOf([]float32{1.25, 3.75}, float32s); !reflect.DeepEqual(got, []float32{0, 3}) {
+		t.Errorf("RoundOf(float32) = %v, want %v", got, []float32{0, 3})
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/ThinkingLogic/jenks
 
-go 1.12
+go 1.18
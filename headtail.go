@@ -0,0 +1,27 @@
+package jenks
+
+import "sort"
+
+// HeadTailBreaks classifies data with a heavy-tailed distribution (e.g. following a power law), using the
+// head/tail breaks method (https://arxiv.org/abs/1209.2801). The data is split at its mean into the 'head' (the values
+// above the mean) and the 'tail'; while the head contains less than headRatioLimit of the values (typically 0.4),
+// it is split again at its own mean, and so on. The number of classes is therefore determined by the data.
+//
+// Each break is the lowest value in its class, in the same form as NaturalBreaks, so the breaks can be passed to Round.
+func HeadTailBreaks(data []float64, headRatioLimit float64) []float64 {
+	data = sortData(data)
+	if len(data) == 0 {
+		return []float64{}
+	}
+
+	breaks := []float64{data[0]}
+	for current := data; ; {
+		mean := mean(current)
+		head := current[sort.Search(len(current), func(i int) bool { return current[i] > mean }):]
+		if len(head) == 0 || float64(len(head))/float64(len(current)) >= headRatioLimit {
+			return breaks
+		}
+		breaks = append(breaks, head[0])
+		current = head
+	}
+}
@@ -0,0 +1,51 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeadTailBreaks(t *testing.T) {
+	// values following a power law: 1000/rank
+	powerLaw := make([]float64, 100)
+	for i := range powerLaw {
+		powerLaw[i] = float64(1000 / (i + 1))
+	}
+
+	tests := []struct {
+		name           string
+		data           []float64
+		headRatioLimit float64
+		want           []float64
+	}{
+		{name: "small", data: []float64{10, 1, 1, 1, 1, 1, 1, 1, 2, 2, 3}, headRatioLimit: 0.4, want: []float64{1, 3}},
+		{name: "larger head ratio", data: []float64{10, 1, 1, 1, 1, 1, 1, 1, 2, 2, 3}, headRatioLimit: 0.6, want: []float64{1, 3, 10}},
+		{name: "power law", data: powerLaw, headRatioLimit: 0.4, want: []float64{10, 52, 200}},
+		{name: "uniform", data: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, headRatioLimit: 0.4, want: []float64{1}},
+		{name: "one unique value", data: []float64{4, 4, 4}, headRatioLimit: 0.4, want: []float64{4}},
+		{name: "empty", data: []float64{}, headRatioLimit: 0.4, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HeadTailBreaks(tt.data, tt.headRatioLimit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HeadTailBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadTailBreaksRound(t *testing.T) {
+	data := make([]float64, 100)
+	for i := range data {
+		data[i] = 1000 / float64(i+1)
+	}
+	breaks := HeadTailBreaks(data, 0.4)
+	rounded := Round(breaks, data)
+
+	classifier, roundedClassifier := NewClassifier(breaks, 1000), NewClassifier(rounded, 1000)
+	for _, v := range data {
+		if classifier.Classify(v) != roundedClassifier.Classify(v) {
+			t.Errorf("rounding breaks %v to %v changed the class of %v", breaks, rounded, v)
+		}
+	}
+}
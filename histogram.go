@@ -0,0 +1,73 @@
+package jenks
+
+// HistogramNaturalBreaks returns the best nClasses natural breaks in data given as a histogram:
+// each of the values occurs the corresponding count times. The breaks are the same as NaturalBreaks would return
+// for the expanded data, but computed over the distinct values only - which is much faster when values are repeated.
+// Values with a count of zero (or less) are ignored; values and counts must have the same length.
+func HistogramNaturalBreaks(values []float64, counts []int, nClasses int) []float64 {
+	return HistogramNaturalClassification(values, counts, nClasses).Breaks()
+}
+
+// HistogramNaturalBreaksE is like HistogramNaturalBreaks, but returns an error instead of panicking or returning
+// meaningless breaks when the data is empty, contains NaN or infinite values, the counts are invalid or nClasses is less than 1.
+func HistogramNaturalBreaksE(values []float64, counts []int, nClasses int) ([]float64, error) {
+	if err := validateData(values); err != nil {
+		return nil, err
+	}
+	if len(values) != len(counts) {
+		return nil, ErrInvalidWeights
+	}
+	for _, c := range counts {
+		if c < 0 {
+			return nil, ErrInvalidWeights
+		}
+	}
+	if nClasses < 1 {
+		return nil, ErrInvalidClassCount
+	}
+	c := HistogramNaturalClassification(values, counts, nClasses)
+	if len(c.Classes) == 0 {
+		// every value had a count of zero
+		return nil, ErrEmptyData
+	}
+	return c.Breaks(), nil
+}
+
+// HistogramMapNaturalBreaks is like HistogramNaturalBreaks, with the histogram given as a map from each value to its count.
+func HistogramMapNaturalBreaks(histogram map[float64]int, nClasses int) []float64 {
+	values := make([]float64, 0, len(histogram))
+	counts := make([]int, 0, len(histogram))
+	for v, c := range histogram {
+		values = append(values, v)
+		counts = append(counts, c)
+	}
+	return HistogramNaturalBreaks(values, counts, nClasses)
+}
+
+// HistogramNaturalClassification classifies data given as a histogram into the best nClasses natural breaks
+// (see HistogramNaturalBreaks), returning the statistics of each class. The Data of the result holds each
+// distinct value once, with Weights holding its count - so the Weight of each class is the number of data points in it.
+func HistogramNaturalClassification(values []float64, counts []int, nClasses int) *Classification {
+	weights := make([]float64, len(counts))
+	for i, c := range counts {
+		weights[i] = float64(c)
+	}
+
+	data, weights := mergeWeights(values, weights)
+	if len(data) == 0 {
+		return &Classification{Data: data, Weights: weights}
+	}
+	if nClasses >= len(data) {
+		return uniqueClassification(data, weights)
+	}
+
+	// NaturalBreaks leaves the largest data point out of the optimisation (see forEachBreak),
+	// so do the same here, in order to return the same breaks as the expanded data.
+	last := len(data)
+	optimised := copyFloat64s(weights)
+	if optimised[last-1]--; optimised[last-1] == 0 {
+		last--
+	}
+
+	return newClassification(data, weights, weightedBoundaries(data[:last], optimised[:last], last, nClasses))
+}
@@ -0,0 +1,123 @@
+package jenks
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestHistogramNaturalBreaks(t *testing.T) {
+	type args struct {
+		values   []float64
+		counts   []int
+		nClasses int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []float64
+	}{
+		{name: "three breaks",
+			args: args{nClasses: 3, values: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}, counts: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+			want: []float64{1, 12, 21}},
+		{name: "more breaks than unique values",
+			args: args{nClasses: 4, values: []float64{1.3, 1.1, 1.2}, counts: []int{3, 3, 3}},
+			want: []float64{1.1, 1.2, 1.3}},
+		{name: "one unique value",
+			args: args{nClasses: 4, values: []float64{1}, counts: []int{4}},
+			want: []float64{1}},
+		{name: "zero counts are ignored",
+			args: args{nClasses: 2, values: []float64{1, 2, 10, 11, 50}, counts: []int{5, 5, 5, 5, 0}},
+			want: []float64{1, 10}},
+		{name: "empty",
+			args: args{nClasses: 2, values: []float64{}, counts: []int{}},
+			want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HistogramNaturalBreaks(tt.args.values, tt.args.counts, tt.args.nClasses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HistogramNaturalBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogramNaturalBreaksMatchesExpandedData(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	ages := make([]float64, 2000)
+	for i := range ages {
+		ages[i] = float64(random.Intn(90))
+	}
+
+	tests := []struct {
+		name string
+		data []float64
+	}{
+		{name: "benchmark data", data: benchmarkData},
+		{name: "ages", data: ages},
+		{name: "single maximum", data: []float64{1, 1, 2, 2, 2, 5, 5, 6, 9, 9, 9, 9, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			histogram := map[float64]int{}
+			for _, v := range tt.data {
+				histogram[v]++
+			}
+			values, counts := []float64{}, []int{}
+			for v, c := range histogram {
+				values = append(values, v)
+				counts = append(counts, c)
+			}
+
+			for nClasses := 1; nClasses <= 10; nClasses++ {
+				want := NaturalClassification(tt.data, nClasses)
+				got := HistogramNaturalClassification(values, counts, nClasses)
+				if !reflect.DeepEqual(got.Breaks(), want.Breaks()) {
+					t.Errorf("HistogramNaturalBreaks(%d) = %v, want %v", nClasses, got.Breaks(), want.Breaks())
+					continue
+				}
+				for i := range got.Classes {
+					if got.Classes[i].Weight != float64(want.Classes[i].Count) {
+						t.Errorf("HistogramNaturalClassification(%d).Classes[%d].Weight = %v, want %v", nClasses, i, got.Classes[i].Weight, want.Classes[i].Count)
+					}
+				}
+				if m := HistogramMapNaturalBreaks(histogram, nClasses); !reflect.DeepEqual(m, want.Breaks()) {
+					t.Errorf("HistogramMapNaturalBreaks(%d) = %v, want %v", nClasses, m, want.Breaks())
+				}
+			}
+		})
+	}
+}
+
+func TestHistogramNaturalBreaksE(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		counts  []int
+		wantErr error
+	}{
+		{name: "valid", values: []float64{1, 2, 10, 11}, counts: []int{1, 2, 3, 4}},
+		{name: "empty", values: []float64{}, counts: []int{}, wantErr: ErrEmptyData},
+		{name: "all zero counts", values: []float64{1, 2}, counts: []int{0, 0}, wantErr: ErrEmptyData},
+		{name: "mismatched lengths", values: []float64{1, 2}, counts: []int{1}, wantErr: ErrInvalidWeights},
+		{name: "negative count", values: []float64{1, 2}, counts: []int{1, -1}, wantErr: ErrInvalidWeights},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := HistogramNaturalBreaksE(tt.values, tt.counts, 2); err != tt.wantErr {
+				t.Errorf("HistogramNaturalBreaksE() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func BenchmarkHistogramNaturalBreaks(b *testing.B) {
+	histogram := map[float64]int{}
+	for _, v := range largeBenchmarkData {
+		histogram[float64(int(v*10))]++
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = HistogramMapNaturalBreaks(histogram, 10)
+	}
+}
@@ -0,0 +1,107 @@
+package jenks
+
+import "math"
+
+// The classification methods in this file are alternatives to natural breaks, for comparison.
+// They all return the lower bound of each class, in the same form as NaturalBreaks - so the breaks can be
+// passed to Round, EvaluateBreaks, NewClassifier or BreaksClassification.
+
+// EqualIntervalBreaks divides the range of the data into nClasses classes of equal width.
+func EqualIntervalBreaks(data []float64, nClasses int) []float64 {
+	min, max, ok := dataRange(data)
+	if !ok {
+		return []float64{}
+	}
+	if min == max || nClasses < 1 {
+		return []float64{min}
+	}
+
+	breaks := make([]float64, nClasses)
+	width := (max - min) / float64(nClasses)
+	for i := range breaks {
+		breaks[i] = min + float64(i)*width
+	}
+	return breaks
+}
+
+// QuantileBreaks divides the data into nClasses classes containing (as near as possible) the same number of values.
+// Each break is the lowest value in its class. Where repeated values would make a class empty, the class is dropped,
+// so fewer than nClasses breaks may be returned.
+func QuantileBreaks(data []float64, nClasses int) []float64 {
+	data = sortData(data)
+	if len(data) == 0 {
+		return []float64{}
+	}
+	if nClasses < 1 {
+		nClasses = 1
+	}
+
+	breaks := make([]float64, 0, nClasses)
+	for i := 0; i < nClasses; i++ {
+		b := data[i*len(data)/nClasses]
+		if len(breaks) == 0 || b != breaks[len(breaks)-1] {
+			breaks = append(breaks, b)
+		}
+	}
+	return breaks
+}
+
+// StandardDeviationBreaks divides the data into classes of width interval standard deviations (e.g. 1, 0.5 or 0.25),
+// with a break at the mean. The first break is the minimum value in the data.
+func StandardDeviationBreaks(data []float64, interval float64) []float64 {
+	min, max, ok := dataRange(data)
+	if !ok {
+		return []float64{}
+	}
+	width := interval * math.Sqrt(sumOfSquareDeviations(data)/float64(len(data)))
+	if !(width > 0) {
+		return []float64{min}
+	}
+
+	// the breaks are at mean + i*width, for every i that lies within the range of the data
+	mean := mean(data)
+	breaks := []float64{min}
+	for i := math.Floor((min-mean)/width) + 1; mean+i*width <= max; i++ {
+		breaks = append(breaks, mean+i*width)
+	}
+	return breaks
+}
+
+// GeometricIntervalBreaks divides the range of the data into nClasses classes whose widths increase by a constant factor,
+// which suits data with a skewed distribution. If the data includes values of zero or less,
+// it is shifted so that the minimum value becomes 1 before the intervals are calculated.
+func GeometricIntervalBreaks(data []float64, nClasses int) []float64 {
+	min, max, ok := dataRange(data)
+	if !ok {
+		return []float64{}
+	}
+	if min == max || nClasses < 1 {
+		return []float64{min}
+	}
+
+	shift := 0.0
+	if min <= 0 {
+		shift = 1 - min
+	}
+	ratio := math.Pow((max+shift)/(min+shift), 1/float64(nClasses))
+
+	breaks := make([]float64, nClasses)
+	breaks[0] = min
+	for i := 1; i < nClasses; i++ {
+		breaks[i] = (min+shift)*math.Pow(ratio, float64(i)) - shift
+	}
+	return breaks
+}
+
+// dataRange returns the minimum and maximum values in the data, or false if the data is empty.
+func dataRange(data []float64) (float64, float64, bool) {
+	if len(data) == 0 {
+		return 0, 0, false
+	}
+	min, max := data[0], data[0]
+	for _, v := range data {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return min, max, true
+}
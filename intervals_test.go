@@ -0,0 +1,123 @@
+package jenks
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualIntervalBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		nClasses int
+		want     []float64
+	}{
+		{name: "four classes", data: []float64{29, 1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28}, nClasses: 4, want: []float64{1, 8, 15, 22}},
+		{name: "one unique value", data: []float64{5, 5}, nClasses: 4, want: []float64{5}},
+		{name: "empty", data: []float64{}, nClasses: 4, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualIntervalBreaks(tt.data, tt.nClasses); !floatsEqual(got, tt.want) {
+				t.Errorf("EqualIntervalBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantileBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		nClasses int
+		want     []float64
+	}{
+		{name: "four classes", data: []float64{29, 1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28}, nClasses: 4, want: []float64{1, 12, 21, 27}},
+		{name: "uneven classes", data: []float64{1, 2, 3, 4, 5, 6, 7}, nClasses: 3, want: []float64{1, 3, 5}},
+		{name: "repeated values", data: []float64{1, 1, 1, 1, 1, 1, 2, 3}, nClasses: 4, want: []float64{1, 2}},
+		{name: "empty", data: []float64{}, nClasses: 4, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuantileBreaks(tt.data, tt.nClasses); !floatsEqual(got, tt.want) {
+				t.Errorf("QuantileBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandardDeviationBreaks(t *testing.T) {
+	// mean 5, standard deviation 2
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	tests := []struct {
+		name     string
+		interval float64
+		want     []float64
+	}{
+		{name: "one standard deviation", interval: 1, want: []float64{2, 3, 5, 7, 9}},
+		{name: "half a standard deviation", interval: 0.5, want: []float64{2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "two standard deviations", interval: 2, want: []float64{2, 5, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StandardDeviationBreaks(data, tt.interval); !floatsEqual(got, tt.want) {
+				t.Errorf("StandardDeviationBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := StandardDeviationBreaks([]float64{3, 3}, 1); !floatsEqual(got, []float64{3}) {
+		t.Errorf("StandardDeviationBreaks(one unique value) = %v, want [3]", got)
+	}
+}
+
+func TestGeometricIntervalBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		nClasses int
+		want     []float64
+	}{
+		{name: "powers of ten", data: []float64{1, 5, 50, 500, 10000}, nClasses: 4, want: []float64{1, 10, 100, 1000}},
+		{name: "including zero", data: []float64{0, 5, 50, 500, 9999}, nClasses: 4, want: []float64{0, 9, 99, 999}},
+		{name: "one unique value", data: []float64{5, 5}, nClasses: 4, want: []float64{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GeometricIntervalBreaks(tt.data, tt.nClasses); !floatsEqual(got, tt.want) {
+				t.Errorf("GeometricIntervalBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlternativeBreaksAreInterchangeable(t *testing.T) {
+	for name, breaks := range map[string][]float64{
+		"equal interval":     EqualIntervalBreaks(benchmarkData, 5),
+		"quantile":           QuantileBreaks(benchmarkData, 5),
+		"standard deviation": StandardDeviationBreaks(benchmarkData, 1),
+		"geometric interval": GeometricIntervalBreaks(benchmarkData, 5),
+	} {
+		classifier := NewClassifier(breaks, 99.2)
+		rounded := NewClassifier(Round(breaks, benchmarkData), 99.2)
+		for _, v := range benchmarkData {
+			if classifier.Classify(v) != rounded.Classify(v) {
+				t.Errorf("%s: rounding breaks %v to %v changed the class of %v", name, breaks, rounded.Breaks, v)
+			}
+		}
+		if gvf := GVF(breaks, benchmarkData); gvf <= 0 || gvf > NaturalClassification(benchmarkData, len(breaks)).GVF+1e-12 {
+			t.Errorf("%s: GVF(%v) = %v, which is not between 0 and the natural breaks GVF", name, breaks, gvf)
+		}
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
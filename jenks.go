@@ -12,73 +12,26 @@ import (
 // Based on the javascript implementation: https://gist.github.com/tmcw/4977508
 // though that implementation has a bug - it has been fixed here.
 
+// BestNaturalBreaks returns the natural breaks for the smallest number of classes (up to maxClasses)
+// whose goodness of variance fit is at least minGvf - or the best fitting breaks if none reach minGvf.
 func BestNaturalBreaks(data []float64, maxClasses int, minGvf float64) []float64 {
-	data = sortData(data)
-
-	uniq := countUniqueValues(data)
-	if maxClasses > uniq {
-		maxClasses = uniq
-	}
-
-	lowerClassLimits, _ := getMatrices(data, maxClasses)
-	var bestGvf float64
-	var bestClass = 1
-
-	for nClasses := 2; nClasses <= maxClasses; nClasses++ {
-		gvf := goodnessOfVarianceFit(data, lowerClassLimits, maxClasses, nClasses, uniq)
-
-		if gvf > bestGvf {
-			bestGvf, bestClass = gvf, nClasses
-		}
-
-		if gvf >= minGvf {
-			break
-		}
-	}
-
-	return breaks(data, lowerClassLimits, maxClasses, bestClass, uniq)
+	return BestNaturalClassification(data, maxClasses, minGvf).Breaks()
 }
 
 // NaturalBreaks returns the best nClasses natural breaks in the data,
 // using the Jenks natural breaks classification method (http://en.wikipedia.org/wiki/Jenks_natural_breaks_optimization).
 // It tries to maximize the similarity of numbers in groups while maximizing the distance between the groups.
 func NaturalBreaks(data []float64, nClasses int) []float64 {
-	// sort data in numerical order, since this is expected by the matrices function
-	data = sortData(data)
-
-	// sanity check
-	uniq := countUniqueValues(data)
-	if nClasses >= uniq {
-		return deduplicate(data)
-	}
-
-	// get our basic matrices (we only need lower class limits here)
-	lowerClassLimits, _ := getMatrices(data, nClasses)
-
-	// extract nClasses out of the computed matrices
-	return breaks(data, lowerClassLimits, nClasses, nClasses, uniq)
+	return NaturalClassification(data, nClasses).Breaks()
 }
 
 // AllNaturalBreaks finds all natural breaks in the data, for every set of breaks between 2 breaks and maxClasses.
 // Uses the Jenks natural breaks classification method (http://en.wikipedia.org/wiki/Jenks_natural_breaks_optimization).
 // It tries to maximize the similarity of numbers in groups while maximizing the distance between the groups.
 func AllNaturalBreaks(data []float64, maxClasses int) [][]float64 {
-	// sort data in numerical order, since this is expected by the matrices function
-	data = sortData(data)
-
-	// sanity check
-	uniq := countUniqueValues(data)
-	if maxClasses > uniq {
-		maxClasses = uniq
-	}
-
-	// get our basic matrices (we only need lower class limits here)
-	lowerClassLimits, _ := getMatrices(data, maxClasses)
-
-	// extract nClasses out of the computed matrices
 	allBreaks := [][]float64{}
-	for nClasses := 2; nClasses <= maxClasses; nClasses++ {
-		allBreaks = append(allBreaks, breaks(data, lowerClassLimits, maxClasses, nClasses, uniq))
+	for _, c := range AllNaturalClassifications(data, maxClasses) {
+		allBreaks = append(allBreaks, c.Breaks())
 	}
 	return allBreaks
 }
@@ -90,19 +43,25 @@ func Round(breaks []float64, data []float64) []float64 {
 	data = sortData(data)
 	rounded := make([]float64, len(breaks))
 	for breakIdx := range breaks {
-		// floor is the value that this break must remain above
-		dataIdx := sort.SearchFloat64s(data, breaks[breakIdx])
-		var floor float64
-		if dataIdx == 0 { // make sure we can't go below breaks[i] - (breaks[i+1]-breaks[i])
-			floor = data[0] - (breaks[breakIdx+1] - breaks[breakIdx])
-		} else {
-			floor = data[dataIdx-1]
-		}
-		rounded[breakIdx] = roundValue(breaks[breakIdx], floor)
+		rounded[breakIdx] = roundValue(breaks[breakIdx], roundingFloor(breaks, data, breakIdx))
 	}
 	return rounded
 }
 
+// roundingFloor returns the value that the given break must remain above, when rounded, to keep the membership
+// of every class of the sorted data unchanged: the largest value in the data below the break.
+func roundingFloor(breaks []float64, data []float64, breakIdx int) float64 {
+	dataIdx := sort.SearchFloat64s(data, breaks[breakIdx])
+	if dataIdx == 0 { // make sure we can't go below breaks[i] - (breaks[i+1]-breaks[i])
+		next := data[len(data)-1] // with a single break, the upper bound is the maximum value in the data
+		if breakIdx+1 < len(breaks) {
+			next = breaks[breakIdx+1]
+		}
+		return data[0] - (next - breaks[breakIdx])
+	}
+	return data[dataIdx-1]
+}
+
 // roundValue works by replacing each digit (from right to left) with 0 until the value is no longer above the floor value.
 func roundValue(initialValue float64, floor float64) float64 {
 	b := []byte(strings.Trim(fmt.Sprintf("%f", initialValue), "0"))
@@ -129,19 +88,6 @@ func sortData(data []float64) []float64 {
 	return data
 }
 
-// deduplicate returns a de-duplicated copy of the given slice, retaining the original order.
-func deduplicate(data []float64) []float64 {
-	keys := make(map[float64]bool)
-	uniq := []float64{}
-	for _, entry := range data {
-		if _, value := keys[entry]; !value {
-			keys[entry] = true
-			uniq = append(uniq, entry)
-		}
-	}
-	return uniq
-}
-
 // countUniqueValues returns the number of unique values in the sorted array of
 // data points passed as arguments. This function is used as an optimization to
 // avoid calling deduplicate for the common case where there are more values
@@ -161,6 +107,12 @@ func countUniqueValues(data []float64) int {
 // getMatrices Computes the matrices required for Jenks breaks.
 // These matrices can be used for any classing of data with 'classes <= n_classes'
 func getMatrices(data []float64, nClasses int) ([]int, []float64) {
+	return getWeightedMatrices(data, nil, nClasses)
+}
+
+// getWeightedMatrices computes the matrices required for Jenks breaks, where each data point has the corresponding weight.
+// If weights is nil, every data point has a weight of 1.
+func getWeightedMatrices(data []float64, weights []float64, nClasses int) ([]int, []float64) {
 	x := len(data) + 1
 	y := nClasses + 1
 	n := mat2len(x, y)
@@ -194,7 +146,7 @@ func getMatrices(data []float64, nClasses int) ([]int, []float64) {
 		sum := 0.0
 		// 'ZSQ' originally. the sum of squares of values seen thus far
 		sumSquares := 0.0
-		// 'WT' originally. 'w' is the number of data points considered so far.
+		// 'WT' originally. 'w' is the number (or total weight) of data points considered so far.
 		// it's used as the divisor in floating-point math, so using float rather than int
 		w := 0.0
 
@@ -207,11 +159,15 @@ func getMatrices(data []float64, nClasses int) ([]int, []float64) {
 
 			// here we're estimating variance for each potential classing
 			// of the data, for each potential number of classes.
-			w++
+			wt := 1.0
+			if weights != nil {
+				wt = weights[currentIndex]
+			}
+			w += wt
 
 			// increase the current sum and sum-of-squares
-			sum += val
-			sumSquares += val * val
+			sum += wt * val
+			sumSquares += wt * val * val
 
 			// the variance at this point in the sequence is the difference
 			// between the sum of squares and the total x 2, over the number
@@ -269,10 +225,16 @@ func forEachBreak(data []float64, lowerClassLimits []int, maxClasses, nClasses,
 		return
 	}
 
+	forEachLimit(lowerClassLimits, len(data)-1, maxClasses, nClasses, do)
+}
+
+// forEachLimit walks the lowerClassLimits matrix back from the class ending at data point 'last' (counting from 1),
+// calling do with the index of the lowest data point in each class.
+func forEachLimit(lowerClassLimits []int, last, maxClasses, nClasses int, do func(class, boundary int)) {
 	y := maxClasses + 1
 	// the lowerClassLimits matrix is used as indexes into itself here:
 	// the next value of `k` is obtained from .
-	k := len(data) - 1
+	k := last
 
 	for i := nClasses; i > 1; i-- {
 		k = lowerClassLimits[mat2idx(k, i, y)] - 1
@@ -284,19 +246,6 @@ func forEachBreak(data []float64, lowerClassLimits []int, maxClasses, nClasses,
 	do(1, 0)
 }
 
-// breaks is the second part of the jenks recipe:
-// take the calculated matrices and derive an array of n breaks.
-func breaks(data []float64, lowerClassLimits []int, maxClasses, nClasses, uniq int) []float64 {
-	classBoundaries := make([]float64, 0, nClasses)
-
-	forEachBreak(data, lowerClassLimits, maxClasses, nClasses, uniq, func(class, boundary int) {
-		classBoundaries = append(classBoundaries, data[boundary])
-	})
-
-	reverse(classBoundaries)
-	return classBoundaries
-}
-
 func mat2len(x, y int) int {
 	return x * y
 }
@@ -330,6 +279,9 @@ func sumOfSquareDeviations(data []float64) float64 {
 	return sum
 }
 
+// goodnessOfVarianceFit is the measure BestNaturalBreaks uses to choose the number of classes.
+// Note that it omits the final class from the sum of squared deviations from the class means,
+// so it differs from Classification.GVF; it is retained so that BestNaturalBreaks keeps choosing the same breaks.
 func goodnessOfVarianceFit(data []float64, lowerClassLimits []int, maxClasses, nClasses, uniq int) float64 {
 	boundaries := make([]int, nClasses)
 
@@ -360,11 +312,3 @@ func goodnessOfVarianceFit(data []float64, lowerClassLimits []int, maxClasses, n
 
 	return (sdam - sdcm) / sdam
 }
-
-func reverse(data []float64) {
-	for i, j := 0, len(data)-1; i < j; {
-		data[i], data[j] = data[j], data[i]
-		i++
-		j--
-	}
-}
@@ -1,7 +1,9 @@
 package jenks
 
 import (
+	"math/rand"
 	"reflect"
+	"runtime"
 	"testing"
 )
 
@@ -203,3 +205,42 @@ func BenchmarkNaturalBreaks(b *testing.B) {
 		_ = NaturalBreaks(benchmarkData, 10)
 	}
 }
+
+func BenchmarkNaturalBreaksLinearMemory(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NaturalBreaksWithOptions(benchmarkData, 10, Options{Method: MethodLinearMemory})
+	}
+}
+
+var largeBenchmarkData = func() []float64 {
+	random := rand.New(rand.NewSource(1))
+	data := make([]float64, 5000)
+	for i := range data {
+		data[i] = random.NormFloat64()
+	}
+	return data
+}()
+
+func BenchmarkNaturalBreaksFast(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NaturalBreaksWithOptions(benchmarkData, 10, Options{Method: MethodFast})
+	}
+}
+
+func BenchmarkNaturalBreaksLarge(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = NaturalBreaks(largeBenchmarkData, 10)
+	}
+}
+
+func BenchmarkNaturalBreaksLargeFast(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NaturalBreaksWithOptions(largeBenchmarkData, 10, Options{Method: MethodFast})
+	}
+}
+
+func BenchmarkNaturalBreaksLargeParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NaturalBreaksWithOptions(largeBenchmarkData, 10, Options{Workers: runtime.NumCPU()})
+	}
+}
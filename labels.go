@@ -0,0 +1,132 @@
+package jenks
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LabelOptions configures the labels returned by Labels. The zero value gives labels such as "0 – 10".
+type LabelOptions struct {
+	// Separator is placed between the lower and upper bounds of each class. The default is " – ".
+	Separator string
+	// OpenEnded, if set, labels the last class with only its lower bound, e.g. "≥ 27", rather than "27 – 29".
+	OpenEnded bool
+	// OpenEndedPrefix precedes the lower bound of an open ended last class. The default is "≥ ".
+	OpenEndedPrefix string
+
+	// FixedDecimalPlaces, if set, formats every value with DecimalPlaces decimal places (0 if it is negative).
+	// Otherwise, every value is formatted with as many decimal places as needed to show all of the breaks exactly
+	// - so the breaks should be rounded first, e.g. with Round or RoundWithOptions.
+	FixedDecimalPlaces bool
+	DecimalPlaces      int
+	// ThousandsSeparator, if not empty, separates each group of three digits before the decimal point, e.g. ",".
+	ThousandsSeparator string
+	// Prefix and Suffix are placed before and after every value, e.g. "$", or " ms".
+	Prefix, Suffix string
+	// Percent, if set, formats every value multiplied by 100, followed by "%" (before any Suffix).
+	Percent bool
+}
+
+// Labels returns a label for each of the classes defined by the breaks (as returned by NaturalBreaks or Round),
+// where dataMax is the upper bound of the last class - usually the maximum value in the data.
+func Labels(breaks []float64, dataMax float64, opts LabelOptions) []string {
+	if opts.Separator == "" {
+		opts.Separator = " – "
+	}
+	if opts.OpenEndedPrefix == "" {
+		opts.OpenEndedPrefix = "≥ "
+	}
+
+	decimalPlaces := 0
+	if opts.FixedDecimalPlaces {
+		if opts.DecimalPlaces > 0 {
+			decimalPlaces = opts.DecimalPlaces
+		}
+	} else {
+		for _, b := range breaks {
+			d := decimalPlacesNeeded(b)
+			if opts.Percent {
+				d -= 2
+			}
+			if d > decimalPlaces {
+				decimalPlaces = d
+			}
+		}
+	}
+
+	labels := make([]string, len(breaks))
+	for i, b := range breaks {
+		lower := formatLabelValue(b, decimalPlaces, opts)
+		switch {
+		case i+1 < len(breaks):
+			labels[i] = lower + opts.Separator + formatLabelValue(breaks[i+1], decimalPlaces, opts)
+		case opts.OpenEnded:
+			labels[i] = opts.OpenEndedPrefix + lower
+		default:
+			labels[i] = lower + opts.Separator + formatLabelValue(dataMax, decimalPlaces, opts)
+		}
+	}
+	return labels
+}
+
+// decimalPlacesNeeded returns the number of decimal places needed to format the value exactly (in its shortest form).
+func decimalPlacesNeeded(value float64) int {
+	s := strconv.FormatFloat(value, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// formatLabelValue formats a single value of a label.
+func formatLabelValue(value float64, decimalPlaces int, opts LabelOptions) string {
+	var s string
+	if opts.Percent {
+		// scaling the formatted value rather than the value itself avoids floating point noise, e.g. 0.07 * 100
+		s = shiftDecimalPoint(strconv.FormatFloat(value, 'f', decimalPlaces+2, 64), 2)
+	} else {
+		s = strconv.FormatFloat(value, 'f', decimalPlaces, 64)
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if opts.ThousandsSeparator != "" {
+		integer, fraction := s, ""
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			integer, fraction = s[:i], s[i:]
+		}
+		var b strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteString(opts.ThousandsSeparator)
+			}
+			b.WriteRune(digit)
+		}
+		s = b.String() + fraction
+	}
+
+	if opts.Percent {
+		s += "%"
+	}
+	return sign + opts.Prefix + s + opts.Suffix
+}
+
+// shiftDecimalPoint multiplies the formatted value s by 10^places, by moving its decimal point.
+// s must have at least places digits after the decimal point.
+func shiftDecimalPoint(s string, places int) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	i := strings.IndexByte(s, '.')
+	integer := strings.TrimLeft(s[:i]+s[i+1:i+1+places], "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if fraction := s[i+1+places:]; fraction != "" {
+		return sign + integer + "." + fraction
+	}
+	return sign + integer
+}
@@ -0,0 +1,65 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		breaks  []float64
+		dataMax float64
+		opts    LabelOptions
+		want    []string
+	}{
+		{name: "default",
+			breaks: []float64{0, 10, 20, 27}, dataMax: 29.1,
+			want: []string{"0 – 10", "10 – 20", "20 – 27", "27 – 29"}},
+		{name: "open ended",
+			breaks: []float64{0, 10, 20, 27}, dataMax: 29.1,
+			opts: LabelOptions{OpenEnded: true},
+			want: []string{"0 – 10", "10 – 20", "20 – 27", "≥ 27"}},
+		{name: "separators",
+			breaks: []float64{0, 10, 20, 27}, dataMax: 29.1,
+			opts: LabelOptions{Separator: " to ", OpenEnded: true, OpenEndedPrefix: "over "},
+			want: []string{"0 to 10", "10 to 20", "20 to 27", "over 27"}},
+		{name: "consistent precision",
+			breaks: []float64{0, 2.5, 10, 12.25}, dataMax: 15,
+			want: []string{"0.00 – 2.50", "2.50 – 10.00", "10.00 – 12.25", "12.25 – 15.00"}},
+		{name: "decimal places",
+			breaks: []float64{0, 2.5, 10}, dataMax: 15,
+			opts: LabelOptions{FixedDecimalPlaces: true, DecimalPlaces: 1},
+			want: []string{"0.0 – 2.5", "2.5 – 10.0", "10.0 – 15.0"}},
+		{name: "no decimal places",
+			breaks: []float64{0, 2.5, 10}, dataMax: 15,
+			opts: LabelOptions{FixedDecimalPlaces: true},
+			want: []string{"0 – 2", "2 – 10", "10 – 15"}},
+		{name: "thousands and units",
+			breaks: []float64{-2500, 0, 12000, 1500000}, dataMax: 2345678.5,
+			opts: LabelOptions{ThousandsSeparator: ",", Prefix: "$"},
+			want: []string{"-$2,500 – $0", "$0 – $12,000", "$12,000 – $1,500,000", "$1,500,000 – $2,345,678"}},
+		{name: "percent",
+			breaks: []float64{0, 0.125, 0.5}, dataMax: 1,
+			opts: LabelOptions{Percent: true, Suffix: " of users"},
+			want: []string{"0.0% of users – 12.5% of users", "12.5% of users – 50.0% of users", "50.0% of users – 100.0% of users"}},
+		{name: "percent without floating point noise",
+			breaks: []float64{0, 0.07, 0.29}, dataMax: 0.5,
+			opts: LabelOptions{Percent: true},
+			want: []string{"0% – 7%", "7% – 29%", "29% – 50%"}},
+		{name: "percent with decimal places",
+			breaks: []float64{-0.0125, 0.07}, dataMax: 1.234,
+			opts: LabelOptions{Percent: true, FixedDecimalPlaces: true, DecimalPlaces: 2},
+			want: []string{"-1.25% – 7.00%", "7.00% – 123.40%"}},
+		{name: "no breaks",
+			breaks: []float64{}, dataMax: 1,
+			want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Labels(tt.breaks, tt.dataMax, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Labels() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package jenks
+
+import "math"
+
+// linearMemoryBoundaries computes the same class boundaries as forEachBreak does from getMatrices(data, nClasses),
+// without allocating the matrices: only O(log(nClasses)) rows of varianceCombinations are held in memory at a time.
+// Rows are recomputed as they're needed while walking back through the classes, costing O(log(nClasses)) more time.
+// Each row is computed using the given number of goroutines (see parallelCells).
+func linearMemoryBoundaries(data []float64, weights []float64, last, nClasses, workers int) []int {
+	boundaries := make([]int, nClasses)
+
+	// to find the lower limit of class c, we need row c-1 of varianceCombinations,
+	// so the rows are visited from nClasses-1 down to 1
+	k := last
+	if nClasses > 1 {
+		reverseRows(data, weights, varianceRow(data, weights, nil, make([]float64, len(data)+1), workers), 1, nClasses-1, workers, func(j int, row []float64) {
+			_, lowerClassLimit := varianceCell(data, weights, row, k)
+			k = lowerClassLimit - 1
+			boundaries[j] = k
+		})
+	}
+
+	// the calculation of classes will never include the lower bound, so we need to explicitly set it
+	boundaries[0] = 0
+	return boundaries
+}
+
+// reverseRows calls do with each row of varianceCombinations from hi down to lo, given the row for lo.
+// It recursively bisects the range, so holds O(log(hi-lo)) rows in memory at a time.
+func reverseRows(data []float64, weights []float64, row []float64, lo, hi, workers int, do func(j int, row []float64)) {
+	if lo == hi {
+		do(lo, row)
+		return
+	}
+
+	// advance to the middle row, alternating between two buffers
+	mid := (lo + hi + 1) / 2
+	next := varianceRow(data, weights, row, make([]float64, len(row)), workers)
+	spare := make([]float64, len(row))
+	for j := lo + 2; j <= mid; j++ {
+		next, spare = varianceRow(data, weights, next, spare, workers), next
+	}
+
+	reverseRows(data, weights, next, mid, hi, workers, do)
+	reverseRows(data, weights, row, lo, mid-1, workers, do)
+}
+
+// varianceRow computes a row of the varianceCombinations matrix (i.e. the optimal variance of the first l data points,
+// for every l, with one more class than prev) into row, returning it. If prev is nil, the row for a single class is computed.
+func varianceRow(data []float64, weights []float64, prev []float64, row []float64, workers int) []float64 {
+	parallelCells(len(row), workers, func(l int) {
+		row[l], _ = varianceCell(data, weights, prev, l)
+	})
+	return row
+}
+
+// varianceCell computes a single cell of the varianceCombinations and lowerClassLimits matrices:
+// the optimal variance of the first l data points (counting from 1), with one more class than prev,
+// and the lower limit of the last class. If prev is nil, the cell for a single class is computed.
+// The arithmetic is performed in exactly the same order as getWeightedMatrices, so the results are identical.
+func varianceCell(data []float64, weights []float64, prev []float64, l int) (float64, int) {
+	if l < 2 {
+		// the initial values of the matrices
+		return 0, l
+	}
+
+	best, limit := math.Inf(+1), 0
+	sum, sumSquares, w := 0.0, 0.0, 0.0
+	variance := 0.0
+
+	for m := 1; m < l+1; m++ {
+		lowerClassLimit := l - m + 1
+		currentIndex := lowerClassLimit - 1
+		val := data[currentIndex]
+
+		wt := 1.0
+		if weights != nil {
+			wt = weights[currentIndex]
+		}
+		w += wt
+		sum += wt * val
+		sumSquares += wt * val * val
+
+		variance = sumSquares - (sum*sum)/w
+		if currentIndex != 0 && prev != nil {
+			if v := prev[currentIndex] + variance; best >= v {
+				best, limit = v, lowerClassLimit
+			}
+		}
+	}
+
+	if prev == nil {
+		return variance, 1
+	}
+	return best, limit
+}
@@ -0,0 +1,77 @@
+package jenks
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestLinearMemoryMatchesMatrix(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	randomData := make([]float64, 300)
+	for i := range randomData {
+		randomData[i] = random.NormFloat64() * 100
+	}
+
+	tests := []struct {
+		name string
+		data []float64
+	}{
+		{name: "small", data: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}},
+		{name: "duplicates", data: []float64{1, 1, 1, 2, 2, 4, 4, 4, 4, 7, 9, 9, 10}},
+		{name: "benchmark data", data: benchmarkData},
+		{name: "random", data: randomData},
+		{name: "large numbers", data: []float64{5.534023222200083e+19, 5.534023222200094e+19, 5.5340232222001e+19, 5.5340232222001e+19, 5.534023222200112e+19, 5.534023222200113e+19}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := sortData(tt.data)
+			uniq := countUniqueValues(data)
+			for nClasses := 1; nClasses < uniq && nClasses <= 12; nClasses++ {
+				lowerClassLimits, _ := getMatrices(data, nClasses)
+				want := classBoundaries(data, lowerClassLimits, nClasses, nClasses, uniq)
+				if got := linearMemoryBoundaries(data, nil, len(data)-1, nClasses, 1); !reflect.DeepEqual(got, want) {
+					t.Errorf("linearMemoryBoundaries(%d) = %v, want %v", nClasses, got, want)
+				}
+
+				got, err := NaturalBreaksWithOptions(tt.data, nClasses, Options{Method: MethodLinearMemory})
+				if err != nil || !reflect.DeepEqual(got, NaturalBreaks(tt.data, nClasses)) {
+					t.Errorf("NaturalBreaksWithOptions(%d, MethodLinearMemory) = %v, %v, want %v", nClasses, got, err, NaturalBreaks(tt.data, nClasses))
+				}
+			}
+		})
+	}
+}
+
+func TestLinearMemoryMatchesMatrixWeighted(t *testing.T) {
+	values := []float64{28.9, 40.7, 52.2, 55.8, 60.1, 64.7, 67.0, 71.2, 73.0, 76.3, 79.2, 83.8, 87.7, 99.2}
+	weights := []float64{1, 1, 2, 2, 2, 2, 2, 4, 4, 3, 3, 2, 2, 2.5}
+
+	for nClasses := 2; nClasses < len(values); nClasses++ {
+		want := WeightedNaturalBreaks(values, weights, nClasses)
+
+		func() {
+			defer func(size int) { MaxMatrixSize = size }(MaxMatrixSize)
+			MaxMatrixSize = 0
+			if got := WeightedNaturalBreaks(values, weights, nClasses); !reflect.DeepEqual(got, want) {
+				t.Errorf("WeightedNaturalBreaks(%d) with linear memory = %v, want %v", nClasses, got, want)
+			}
+		}()
+	}
+}
+
+func TestAutoMethod(t *testing.T) {
+	defer func(size int) { MaxMatrixSize = size }(MaxMatrixSize)
+
+	want := NaturalBreaks(benchmarkData, 6)
+	MaxMatrixSize = 10
+	if got := NaturalBreaks(benchmarkData, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("NaturalBreaks() with linear memory = %v, want %v", got, want)
+	}
+	if got := (Options{}).method(len(benchmarkData), 6); got != MethodLinearMemory {
+		t.Errorf("Options{}.method() = %v, want %v", got, MethodLinearMemory)
+	}
+	if _, err := NaturalBreaksWithOptions(benchmarkData, 6, Options{Method: Method(-1)}); err != ErrInvalidMethod {
+		t.Errorf("NaturalBreaksWithOptions() error = %v, want %v", err, ErrInvalidMethod)
+	}
+}
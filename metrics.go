@@ -0,0 +1,105 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+// Metrics measures how well a classification fits the data.
+type Metrics struct {
+	// SDAM is the sum of squared deviations from the array (data) mean.
+	SDAM float64
+	// SDCM is the sum of squared deviations from the class means.
+	SDCM float64
+	// GVF is the goodness of variance fit: (SDAM - SDCM) / SDAM.
+	GVF float64
+	// ADAM is the sum of absolute deviations from the array (data) mean.
+	ADAM float64
+	// ADCM is the sum of absolute deviations from the class means.
+	ADCM float64
+	// TAI is the tabular accuracy index: (ADAM - ADCM) / ADAM.
+	TAI float64
+	// ClassSDCM holds the contribution of each class to SDCM.
+	ClassSDCM []float64
+	// ClassADCM holds the contribution of each class to ADCM.
+	ClassADCM []float64
+}
+
+// EvaluateBreaks measures how well the given breaks (lower bounds of each class, as returned by NaturalBreaks or Round)
+// fit the data - e.g. to compare natural breaks against hand-picked breaks.
+func EvaluateBreaks(breaks []float64, data []float64) Metrics {
+	return BreaksClassification(breaks, data).Metrics()
+}
+
+// GVF returns the goodness of variance fit of the given breaks to the data: 1 for a perfect fit, down to 0 for no fit.
+func GVF(breaks []float64, data []float64) float64 {
+	return BreaksClassification(breaks, data).GVF
+}
+
+// BreaksClassification classifies the data using the given breaks, as the lower bound of each class.
+// Any values below the first break are included in the first class.
+// The Lower bound of each class in the result is the given break, rather than the lowest value in the class.
+// If there are no breaks, the data is treated as a single class, starting at its minimum.
+func BreaksClassification(breaks []float64, data []float64) *Classification {
+	data = sortData(data)
+	breaks = sortData(breaks)
+	if len(breaks) == 0 && len(data) > 0 {
+		breaks = data[:1]
+	}
+
+	boundaries := make([]int, len(breaks))
+	for i, b := range breaks {
+		if i > 0 {
+			boundaries[i] = sort.SearchFloat64s(data, b)
+		}
+	}
+
+	c := newClassification(data, nil, boundaries)
+	for i := range c.Classes {
+		c.Classes[i].Lower = breaks[i]
+		if c.Classes[i].Count == 0 {
+			c.Classes[i].Upper = breaks[i]
+		}
+	}
+	return c
+}
+
+// Metrics returns the measures of how well the classification fits its data.
+func (c *Classification) Metrics() Metrics {
+	m := Metrics{
+		ClassSDCM: make([]float64, len(c.Classes)),
+		ClassADCM: make([]float64, len(c.Classes)),
+	}
+
+	dataMean := mean(c.Data)
+	if c.Weights != nil {
+		dataMean = weightedMean(c.Data, c.Weights)
+	}
+	for i, v := range c.Data {
+		diff := v - dataMean
+		m.SDAM += c.weight(i) * diff * diff
+		m.ADAM += c.weight(i) * math.Abs(diff)
+	}
+
+	for i, class := range c.Classes {
+		for j := class.Start; j < class.End; j++ {
+			diff := c.Data[j] - class.Mean
+			m.ClassSDCM[i] += c.weight(j) * diff * diff
+			m.ClassADCM[i] += c.weight(j) * math.Abs(diff)
+		}
+		m.SDCM += m.ClassSDCM[i]
+		m.ADCM += m.ClassADCM[i]
+	}
+
+	m.GVF = varianceFit(m.SDAM, m.SDCM)
+	m.TAI = varianceFit(m.ADAM, m.ADCM)
+	return m
+}
+
+// weight returns the weight of the i'th data point.
+func (c *Classification) weight(i int) float64 {
+	if c.Weights == nil {
+		return 1
+	}
+	return c.Weights[i]
+}
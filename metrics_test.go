@@ -0,0 +1,106 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateBreaks(t *testing.T) {
+	data := []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}
+	got := EvaluateBreaks([]float64{1, 12, 21, 27}, data)
+
+	// mean 16.25; each class has deviations of -1, 0, 1 from its mean
+	want := Metrics{
+		SDAM:      1162.25,
+		SDCM:      8,
+		GVF:       (1162.25 - 8) / 1162.25,
+		ADAM:      15.25 + 14.25 + 13.25 + 4.25 + 3.25 + 2.25 + 4.75 + 5.75 + 6.75 + 10.75 + 11.75 + 12.75,
+		ADCM:      8,
+		ClassSDCM: []float64{2, 2, 2, 2},
+		ClassADCM: []float64{2, 2, 2, 2},
+	}
+	want.TAI = (want.ADAM - want.ADCM) / want.ADAM
+
+	if !reflect.DeepEqual(got.ClassSDCM, want.ClassSDCM) || !reflect.DeepEqual(got.ClassADCM, want.ClassADCM) {
+		t.Errorf("EvaluateBreaks() per class = %v, %v, want %v, %v", got.ClassSDCM, got.ClassADCM, want.ClassSDCM, want.ClassADCM)
+	}
+	for _, m := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"SDAM", got.SDAM, want.SDAM},
+		{"SDCM", got.SDCM, want.SDCM},
+		{"GVF", got.GVF, want.GVF},
+		{"ADAM", got.ADAM, want.ADAM},
+		{"ADCM", got.ADCM, want.ADCM},
+		{"TAI", got.TAI, want.TAI},
+	} {
+		if math.Abs(m.got-m.want) > 1e-9 {
+			t.Errorf("EvaluateBreaks().%s = %v, want %v", m.name, m.got, m.want)
+		}
+	}
+}
+
+func TestGVF(t *testing.T) {
+	data := []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 21.1, 22.1, 23.1, 27.1, 28.1, 29.1}
+	breaks := NaturalBreaks(data, 4)
+	natural := NaturalClassification(data, 4).GVF
+
+	if got := GVF(breaks, data); math.Abs(got-natural) > 1e-12 {
+		t.Errorf("GVF(natural breaks) = %v, want %v", got, natural)
+	}
+	// rounding the breaks doesn't change the membership of any class
+	if got := GVF(Round(breaks, data), data); math.Abs(got-natural) > 1e-12 {
+		t.Errorf("GVF(rounded breaks) = %v, want %v", got, natural)
+	}
+	// hand-picked breaks fit worse
+	if got := GVF([]float64{0, 10, 20}, data); got >= natural {
+		t.Errorf("GVF(hand-picked breaks) = %v, want less than %v", got, natural)
+	}
+	if got := GVF([]float64{0}, data); got != 0 {
+		t.Errorf("GVF(single class) = %v, want 0", got)
+	}
+	// no breaks is a single class, not a perfect fit
+	if got := GVF(nil, data); got != 0 {
+		t.Errorf("GVF(no breaks) = %v, want 0", got)
+	}
+	if got := EvaluateBreaks(nil, data); got.GVF != 0 || len(got.ClassSDCM) != 1 {
+		t.Errorf("EvaluateBreaks(no breaks) = %+v, want a single class with GVF 0", got)
+	}
+}
+
+func TestBreaksClassification(t *testing.T) {
+	data := []float64{29, 28, 27, 23, 22, 21, 14, 13, 12, 3, 2, 1}
+	got := BreaksClassification([]float64{20, 5, 10, 50}, data)
+
+	want := []Class{
+		{Lower: 5, Upper: 3, Count: 3, Weight: 3, Sum: 6, Mean: 2, Variance: 2.0 / 3, Start: 0, End: 3},
+		{Lower: 10, Upper: 14, Count: 3, Weight: 3, Sum: 39, Mean: 13, Variance: 2.0 / 3, Start: 3, End: 6},
+		{Lower: 20, Upper: 29, Count: 6, Weight: 6, Sum: 150, Mean: 25, Variance: 58.0 / 6, Start: 6, End: 12},
+		{Lower: 50, Upper: 50, Count: 0, Weight: 0, Sum: 0, Mean: 0, Variance: 0, Start: 12, End: 12},
+	}
+	if !reflect.DeepEqual(got.Classes, want) {
+		t.Errorf("BreaksClassification().Classes = %+v, want %+v", got.Classes, want)
+	}
+	if !reflect.DeepEqual(got.Breaks(), []float64{5, 10, 20, 50}) {
+		t.Errorf("BreaksClassification().Breaks() = %v, want %v", got.Breaks(), []float64{5, 10, 20, 50})
+	}
+}
+
+func TestWeightedMetrics(t *testing.T) {
+	values := []float64{1, 2, 10, 11}
+	weights := []float64{1, 3, 3, 1}
+	got := WeightedNaturalClassification(values, weights, 2).Metrics()
+
+	// class means are 1.75 and 10.25, the data mean is 6
+	if want := (0.5625*1 + 0.0625*3) + (0.0625*3 + 0.5625*1); math.Abs(got.SDCM-want) > 1e-12 {
+		t.Errorf("Metrics().SDCM = %v, want %v", got.SDCM, want)
+	}
+	if want := 25.0*1 + 16*3 + 16*3 + 25*1; math.Abs(got.SDAM-want) > 1e-12 {
+		t.Errorf("Metrics().SDAM = %v, want %v", got.SDAM, want)
+	}
+	if want := 5.0*1 + 4*3 + 4*3 + 5*1; math.Abs(got.ADAM-want) > 1e-12 {
+		t.Errorf("Metrics().ADAM = %v, want %v", got.ADAM, want)
+	}
+}
@@ -0,0 +1,71 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+// NiceSteps is the default series of steps used by RoundNice: breaks are rounded to multiples of 1, 2, 2.5 or 5
+// times a power of ten.
+var NiceSteps = []float64{1, 2, 2.5, 5}
+
+// RoundNice rounds each of the breaks down to a multiple of the largest step possible without changing the membership
+// of any class (as Round does), so that e.g. 1260 may become 1250, where Round would only give 1260 or 1200.
+// The steps are taken from the given series (between 1 and 10) times a power of ten; if steps is nil, NiceSteps is used.
+func RoundNice(breaks []float64, data []float64, steps []float64) []float64 {
+	if steps == nil {
+		steps = NiceSteps
+	}
+	steps = copyFloat64s(steps)
+	sort.Sort(sort.Reverse(sort.Float64Slice(steps)))
+
+	data = sortData(data)
+	rounded := make([]float64, len(breaks))
+	for breakIdx := range breaks {
+		rounded[breakIdx] = roundNiceValue(breaks[breakIdx], roundingFloor(breaks, data, breakIdx), steps)
+	}
+	return rounded
+}
+
+// roundNiceValue rounds value down to a multiple of the largest step (from the descending steps, times a power of ten)
+// that keeps it above the floor. The value is returned unchanged if there is no such step.
+func roundNiceValue(value float64, floor float64, steps []float64) float64 {
+	if !(value > floor) || math.IsInf(value, 0) {
+		return value
+	}
+
+	// any step up to value-floor keeps the value above the floor, so the powers of ten are tried from one
+	// that exceeds both the value and the floor, down to the first that's no greater than value-floor
+	exp := int(math.Ceil(math.Log10(math.Max(math.Abs(value), math.Abs(floor))))) + 1
+	last := int(math.Floor(math.Log10(value - floor)))
+	for ; exp >= last-1; exp-- {
+		for _, step := range steps {
+			if r := floorToStep(value, step, exp); r > floor && r <= value {
+				return r
+			}
+		}
+	}
+	return value
+}
+
+// floorToStep rounds value down to a multiple of step * 10^exp. The multiple is computed so that decimal steps
+// (such as 0.25) give the closest float64 to the decimal result, rather than accumulating rounding errors.
+func floorToStep(value float64, step float64, exp int) float64 {
+	multiple := func(k float64) float64 {
+		if exp >= 0 {
+			return k * step * math.Pow10(exp)
+		}
+		return k * step / math.Pow10(-exp)
+	}
+
+	var k float64
+	if exp >= 0 {
+		k = math.Floor(value / (step * math.Pow10(exp)))
+	} else {
+		k = math.Floor(value * math.Pow10(-exp) / step)
+	}
+	if r := multiple(k); r <= value {
+		return r
+	}
+	return multiple(k - 1)
+}
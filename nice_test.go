@@ -0,0 +1,54 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundNice(t *testing.T) {
+	tests := []struct {
+		name   string
+		breaks []float64
+		data   []float64
+		steps  []float64
+		want   []float64
+	}{
+		{name: "example from README",
+			breaks: []float64{1.1, 12.1, 21.1, 27.1},
+			data:   []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 21.1, 22.1, 23.1, 27.1, 28.1, 29.1},
+			want:   []float64{0, 10, 20, 25}},
+		{name: "two and a half",
+			breaks: []float64{1000, 1270, 2000},
+			data:   []float64{1000, 1230, 1270, 1500, 2000},
+			want:   []float64{1000, 1250, 2000}},
+		{name: "decimals",
+			breaks: []float64{0.1, 0.3337, 0.61},
+			data:   []float64{0.1, 0.2, 0.31, 0.3337, 0.4, 0.61, 0.7},
+			want:   []float64{0, 0.325, 0.5}},
+		{name: "negative",
+			breaks: []float64{-97, -42, 13},
+			data:   []float64{-97, -50, -42, -10, 13, 30},
+			want:   []float64{-100, -45, 0}},
+		{name: "can't be rounded",
+			breaks: []float64{1, 1.0000000000000002},
+			data:   []float64{1, 1.0000000000000002},
+			want:   []float64{1, 1.0000000000000002}},
+		{name: "caller supplied steps",
+			breaks: []float64{1000, 1270, 2000},
+			data:   []float64{1000, 1230, 1270, 1500, 2000},
+			steps:  []float64{1, 3},
+			want:   []float64{1000, 1260, 2000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RoundNice(tt.breaks, tt.data, tt.steps)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RoundNice() = %v, want %v", got, tt.want)
+			}
+			// membership is unchanged
+			if !reflect.DeepEqual(NewClassifier(got, 1e9).ClassifyAll(tt.data), NewClassifier(tt.breaks, 1e9).ClassifyAll(tt.data)) {
+				t.Errorf("RoundNice() = %v changes the membership of the classes of %v", got, tt.breaks)
+			}
+		})
+	}
+}
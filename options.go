@@ -0,0 +1,129 @@
+package jenks
+
+import (
+	"errors"
+	"math"
+)
+
+// Method selects the algorithm used to compute natural breaks. MethodMatrix and MethodLinearMemory compute identical breaks;
+// MethodFast may differ from them where rounding errors dominate (see below).
+type Method int
+
+const (
+	// MethodAuto uses MethodMatrix, unless the matrices would exceed MaxMatrixSize, in which case it uses MethodLinearMemory.
+	MethodAuto Method = iota
+	// MethodMatrix computes the full lower class limits and variance combinations matrices, using O(n*k) memory.
+	MethodMatrix
+	// MethodLinearMemory keeps only O(log(k)) rows of the variance combinations matrix in memory at a time,
+	// using O(n*log(k)) memory at the cost of O(log(k)) times as much computation.
+	MethodLinearMemory
+	// MethodFast computes the breaks in O(n*log(n)*k) time rather than O(n^2*k), using O(n*k) memory.
+	// It computes class variances in a different order to the other methods, so the breaks may differ where
+	// rounding errors dominate the differences between candidate classes - e.g. for values with very small relative spread.
+	MethodFast
+)
+
+// ErrInvalidMethod is returned when Options specifies an unknown Method.
+var ErrInvalidMethod = errors.New("jenks: invalid method")
+
+// Options configures how NaturalBreaksWithOptions computes breaks. The zero value is the default behaviour of NaturalBreaks.
+type Options struct {
+	// Method selects the algorithm used to compute the breaks. It is ignored if the classes are constrained.
+	Method Method
+
+	// MinClassSize, if greater than 1, constrains every class to contain at least this many data points.
+	MinClassSize int
+	// MinClassWidth, if greater than 0, constrains the values in every class to span at least this range
+	// (the difference between the largest and smallest value in the class).
+	//
+	// When the classes are constrained, the breaks are optimal amongst the classifications that satisfy the constraints,
+	// using as many classes (up to nClasses) as the constraints allow. Unlike NaturalBreaks, the largest value counts
+	// towards the variance being minimised, so constraints that aren't binding may still give different breaks.
+	// ErrInfeasible is returned if even a single class would not satisfy them.
+	MinClassWidth float64
+
+	// Pinned holds break values that must be used, e.g. a threshold of 0 or a regulatory limit. The data is split at
+	// each pinned value (which becomes the lower bound of a class), and the remaining classes are distributed between
+	// the resulting segments to minimise the total variance within classes. Every segment has at least one class,
+	// so more than nClasses classes are returned if nClasses is less than the number of segments.
+	// Pinned values at or below the minimum of the data, or above its maximum, have no effect.
+	Pinned []float64
+
+	// Stable, if set, computes the variance of each candidate class using Welford's method, which remains accurate
+	// for values that are large relative to their spread (such as timestamps), where the default computation suffers
+	// from catastrophic cancellation. It computes the full matrices (so ErrTooLarge is returned if they would exceed
+	// MaxMatrixSize), and Method and Workers are ignored.
+	Stable bool
+
+	// Workers, if greater than 1, is the number of goroutines used to compute each row of the matrices
+	// for MethodMatrix and MethodLinearMemory. The breaks are identical to those computed by a single goroutine.
+	// Computing the rows independently repeats some of the arithmetic that a single goroutine shares between them,
+	// so this is only faster with several processors available.
+	Workers int
+}
+
+// NaturalBreaksWithOptions is like NaturalBreaksE, but allows the computation to be configured.
+func NaturalBreaksWithOptions(data []float64, nClasses int, opts Options) ([]float64, error) {
+	c, err := NaturalClassificationWithOptions(data, nClasses, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.Breaks(), nil
+}
+
+// NaturalClassificationWithOptions is like NaturalClassification, but allows the computation to be configured,
+// and returns an error if the data is empty, contains NaN or infinite values, nClasses is less than 1,
+// or the options are invalid.
+func NaturalClassificationWithOptions(data []float64, nClasses int, opts Options) (*Classification, error) {
+	if err := validateData(data); err != nil {
+		return nil, err
+	}
+	if nClasses < 1 {
+		return nil, ErrInvalidClassCount
+	}
+	if opts.Method < MethodAuto || opts.Method > MethodFast {
+		return nil, ErrInvalidMethod
+	}
+	if math.IsNaN(opts.MinClassWidth) {
+		return nil, ErrNaN
+	}
+	if err := validateValues(opts.Pinned); err != nil {
+		return nil, err
+	}
+	data = sortData(data)
+	if len(opts.Pinned) > 0 {
+		return pinnedClassification(data, nClasses, opts)
+	}
+	return optionsClassification(data, nClasses, opts)
+}
+
+// optionsClassification classifies the sorted, valid data into nClasses, ignoring any pinned breaks in the options.
+func optionsClassification(data []float64, nClasses int, opts Options) (*Classification, error) {
+	if opts.constrained() {
+		boundaries, err := constrainedBoundaries(data, nClasses, opts.MinClassSize, opts.MinClassWidth)
+		if err != nil {
+			return nil, err
+		}
+		return newClassification(data, nil, boundaries), nil
+	}
+	if (opts.Method == MethodMatrix || opts.Stable) && nClasses < countUniqueValues(data) && !matrixFits(len(data), nClasses) {
+		return nil, ErrTooLarge
+	}
+	return naturalClassification(data, nClasses, opts), nil
+}
+
+// method returns the method to use to classify n data points into nClasses.
+func (o Options) method(n, nClasses int) Method {
+	if o.Method == MethodAuto {
+		if matrixFits(n, nClasses) {
+			return MethodMatrix
+		}
+		return MethodLinearMemory
+	}
+	return o.Method
+}
+
+// matrixFits returns true if the matrices for classifying n data points into nClasses don't exceed MaxMatrixSize.
+func matrixFits(n, nClasses int) bool {
+	return nClasses+1 <= MaxMatrixSize/(n+1)
+}
@@ -0,0 +1,268 @@
+package jenks
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PaletteKind describes the kind of data a Palette suits.
+type PaletteKind int
+
+const (
+	// Sequential palettes suit ordered data, from low to high - e.g. light to dark.
+	Sequential PaletteKind = iota
+	// Diverging palettes suit ordered data with a critical midpoint - e.g. red to white to blue.
+	Diverging
+	// Qualitative palettes suit categories, with no order between them.
+	Qualitative
+)
+
+// Palette is a ramp of colours, from which class colours can be taken.
+type Palette struct {
+	// Name identifies the palette, e.g. "Blues".
+	Name string
+	// Kind is the kind of data the palette suits.
+	Kind PaletteKind
+	// Colors holds the colours of the ramp, in order. Sequential and diverging palettes are interpolated between them
+	// for numbers of classes without a scheme of their own.
+	Colors []color.RGBA
+	// Schemes holds the colours chosen for particular numbers of classes, keyed by the number of classes.
+	Schemes map[int][]color.RGBA
+}
+
+// The built in palettes are the ColorBrewer (https://colorbrewer2.org) schemes of the same name,
+// by Cynthia Brewer, Mark Harrower and The Pennsylvania State University (Apache License 2.0).
+// The qualitative palettes hold only their largest scheme, as each smaller one is the first of its colours.
+var (
+	Blues = newPalette("Blues", Sequential,
+		"#deebf7 #9ecae1 #3182bd",
+		"#eff3ff #bdd7e7 #6baed6 #2171b5",
+		"#eff3ff #bdd7e7 #6baed6 #3182bd #08519c",
+		"#eff3ff #c6dbef #9ecae1 #6baed6 #3182bd #08519c",
+		"#eff3ff #c6dbef #9ecae1 #6baed6 #4292c6 #2171b5 #084594",
+		"#f7fbff #deebf7 #c6dbef #9ecae1 #6baed6 #4292c6 #2171b5 #084594",
+		"#f7fbff #deebf7 #c6dbef #9ecae1 #6baed6 #4292c6 #2171b5 #08519c #08306b",
+	)
+	Greens = newPalette("Greens", Sequential,
+		"#e5f5e0 #a1d99b #31a354",
+		"#edf8e9 #bae4b3 #74c476 #238b45",
+		"#edf8e9 #bae4b3 #74c476 #31a354 #006d2c",
+		"#edf8e9 #c7e9c0 #a1d99b #74c476 #31a354 #006d2c",
+		"#edf8e9 #c7e9c0 #a1d99b #74c476 #41ab5d #238b45 #005a32",
+		"#f7fcf5 #e5f5e0 #c7e9c0 #a1d99b #74c476 #41ab5d #238b45 #005a32",
+		"#f7fcf5 #e5f5e0 #c7e9c0 #a1d99b #74c476 #41ab5d #238b45 #006d2c #00441b",
+	)
+	Reds = newPalette("Reds", Sequential,
+		"#fee0d2 #fc9272 #de2d26",
+		"#fee5d9 #fcae91 #fb6a4a #cb181d",
+		"#fee5d9 #fcae91 #fb6a4a #de2d26 #a50f15",
+		"#fee5d9 #fcbba1 #fc9272 #fb6a4a #de2d26 #a50f15",
+		"#fee5d9 #fcbba1 #fc9272 #fb6a4a #ef3b2c #cb181d #99000d",
+		"#fff5f0 #fee0d2 #fcbba1 #fc9272 #fb6a4a #ef3b2c #cb181d #99000d",
+		"#fff5f0 #fee0d2 #fcbba1 #fc9272 #fb6a4a #ef3b2c #cb181d #a50f15 #67000d",
+	)
+	Greys = newPalette("Greys", Sequential,
+		"#f0f0f0 #bdbdbd #636363",
+		"#f7f7f7 #cccccc #969696 #525252",
+		"#f7f7f7 #cccccc #969696 #636363 #252525",
+		"#f7f7f7 #d9d9d9 #bdbdbd #969696 #636363 #252525",
+		"#f7f7f7 #d9d9d9 #bdbdbd #969696 #737373 #525252 #252525",
+		"#ffffff #f0f0f0 #d9d9d9 #bdbdbd #969696 #737373 #525252 #252525",
+		"#ffffff #f0f0f0 #d9d9d9 #bdbdbd #969696 #737373 #525252 #252525 #000000",
+	)
+	YlOrRd = newPalette("YlOrRd", Sequential,
+		"#ffeda0 #feb24c #f03b20",
+		"#ffffb2 #fecc5c #fd8d3c #e31a1c",
+		"#ffffb2 #fecc5c #fd8d3c #f03b20 #bd0026",
+		"#ffffb2 #fed976 #feb24c #fd8d3c #f03b20 #bd0026",
+		"#ffffb2 #fed976 #feb24c #fd8d3c #fc4e2a #e31a1c #b10026",
+		"#ffffcc #ffeda0 #fed976 #feb24c #fd8d3c #fc4e2a #e31a1c #b10026",
+		"#ffffcc #ffeda0 #fed976 #feb24c #fd8d3c #fc4e2a #e31a1c #bd0026 #800026",
+	)
+	YlGnBu = newPalette("YlGnBu", Sequential,
+		"#edf8b1 #7fcdbb #2c7fb8",
+		"#ffffcc #a1dab4 #41b6c4 #225ea8",
+		"#ffffcc #a1dab4 #41b6c4 #2c7fb8 #253494",
+		"#ffffcc #c7e9b4 #7fcdbb #41b6c4 #2c7fb8 #253494",
+		"#ffffcc #c7e9b4 #7fcdbb #41b6c4 #1d91c0 #225ea8 #0c2c84",
+		"#ffffd9 #edf8b1 #c7e9b4 #7fcdbb #41b6c4 #1d91c0 #225ea8 #0c2c84",
+		"#ffffd9 #edf8b1 #c7e9b4 #7fcdbb #41b6c4 #1d91c0 #225ea8 #253494 #081d58",
+	)
+
+	RdBu = newPalette("RdBu", Diverging,
+		"#ef8a62 #f7f7f7 #67a9cf",
+		"#ca0020 #f4a582 #92c5de #0571b0",
+		"#ca0020 #f4a582 #f7f7f7 #92c5de #0571b0",
+		"#b2182b #ef8a62 #fddbc7 #d1e5f0 #67a9cf #2166ac",
+		"#b2182b #ef8a62 #fddbc7 #f7f7f7 #d1e5f0 #67a9cf #2166ac",
+		"#b2182b #d6604d #f4a582 #fddbc7 #d1e5f0 #92c5de #4393c3 #2166ac",
+		"#b2182b #d6604d #f4a582 #fddbc7 #f7f7f7 #d1e5f0 #92c5de #4393c3 #2166ac",
+		"#67001f #b2182b #d6604d #f4a582 #fddbc7 #d1e5f0 #92c5de #4393c3 #2166ac #053061",
+		"#67001f #b2182b #d6604d #f4a582 #fddbc7 #f7f7f7 #d1e5f0 #92c5de #4393c3 #2166ac #053061",
+	)
+	RdYlGn = newPalette("RdYlGn", Diverging,
+		"#fc8d59 #ffffbf #91cf60",
+		"#d7191c #fdae61 #a6d96a #1a9641",
+		"#d7191c #fdae61 #ffffbf #a6d96a #1a9641",
+		"#d73027 #fc8d59 #fee08b #d9ef8b #91cf60 #1a9850",
+		"#d73027 #fc8d59 #fee08b #ffffbf #d9ef8b #91cf60 #1a9850",
+		"#d73027 #f46d43 #fdae61 #fee08b #d9ef8b #a6d96a #66bd63 #1a9850",
+		"#d73027 #f46d43 #fdae61 #fee08b #ffffbf #d9ef8b #a6d96a #66bd63 #1a9850",
+		"#a50026 #d73027 #f46d43 #fdae61 #fee08b #d9ef8b #a6d96a #66bd63 #1a9850 #006837",
+		"#a50026 #d73027 #f46d43 #fdae61 #fee08b #ffffbf #d9ef8b #a6d96a #66bd63 #1a9850 #006837",
+	)
+	Spectral = newPalette("Spectral", Diverging,
+		"#fc8d59 #ffffbf #99d594",
+		"#d7191c #fdae61 #abdda4 #2b83ba",
+		"#d7191c #fdae61 #ffffbf #abdda4 #2b83ba",
+		"#d53e4f #fc8d59 #fee08b #e6f598 #99d594 #3288bd",
+		"#d53e4f #fc8d59 #fee08b #ffffbf #e6f598 #99d594 #3288bd",
+		"#d53e4f #f46d43 #fdae61 #fee08b #e6f598 #abdda4 #66c2a5 #3288bd",
+		"#d53e4f #f46d43 #fdae61 #fee08b #ffffbf #e6f598 #abdda4 #66c2a5 #3288bd",
+		"#9e0142 #d53e4f #f46d43 #fdae61 #fee08b #e6f598 #abdda4 #66c2a5 #3288bd #5e4fa2",
+		"#9e0142 #d53e4f #f46d43 #fdae61 #fee08b #ffffbf #e6f598 #abdda4 #66c2a5 #3288bd #5e4fa2",
+	)
+	PuOr = newPalette("PuOr", Diverging,
+		"#f1a340 #f7f7f7 #998ec3",
+		"#e66101 #fdb863 #b2abd2 #5e3c99",
+		"#e66101 #fdb863 #f7f7f7 #b2abd2 #5e3c99",
+		"#b35806 #f1a340 #fee0b6 #d8daeb #998ec3 #542788",
+		"#b35806 #f1a340 #fee0b6 #f7f7f7 #d8daeb #998ec3 #542788",
+		"#b35806 #e08214 #fdb863 #fee0b6 #d8daeb #b2abd2 #8073ac #542788",
+		"#b35806 #e08214 #fdb863 #fee0b6 #f7f7f7 #d8daeb #b2abd2 #8073ac #542788",
+		"#7f3b08 #b35806 #e08214 #fdb863 #fee0b6 #d8daeb #b2abd2 #8073ac #542788 #2d004b",
+		"#7f3b08 #b35806 #e08214 #fdb863 #fee0b6 #f7f7f7 #d8daeb #b2abd2 #8073ac #542788 #2d004b",
+	)
+
+	Set1   = newPalette("Set1", Qualitative, "#e41a1c #377eb8 #4daf4a #984ea3 #ff7f00 #ffff33 #a65628 #f781bf #999999")
+	Set3   = newPalette("Set3", Qualitative, "#8dd3c7 #ffffb3 #bebada #fb8072 #80b1d3 #fdb462 #b3de69 #fccde5 #d9d9d9 #bc80bd #ccebc5 #ffed6f")
+	Paired = newPalette("Paired", Qualitative, "#a6cee3 #1f78b4 #b2df8a #33a02c #fb9a99 #e31a1c #fdbf6f #ff7f00 #cab2d6 #6a3d9a #ffff99 #b15928")
+	Dark2  = newPalette("Dark2", Qualitative, "#1b9e77 #d95f02 #7570b3 #e7298a #66a61e #e6ab02 #a6761d #666666")
+)
+
+// Palettes lists the built in palettes.
+var Palettes = []Palette{Blues, Greens, Reds, Greys, YlOrRd, YlGnBu, RdBu, RdYlGn, Spectral, PuOr, Set1, Set3, Paired, Dark2}
+
+// newPalette returns a palette of the given schemes, each a space separated list of valid hex colours,
+// in increasing size. The largest scheme is the ramp of colours.
+func newPalette(name string, kind PaletteKind, schemes ...string) Palette {
+	p := Palette{Name: name, Kind: kind, Schemes: map[int][]color.RGBA{}}
+	for _, scheme := range schemes {
+		hex := strings.Fields(scheme)
+		colors := make([]color.RGBA, len(hex))
+		for i, h := range hex {
+			c, err := ParseHexColor(h)
+			if err != nil {
+				panic(err)
+			}
+			colors[i] = c
+		}
+		p.Schemes[len(colors)], p.Colors = colors, colors
+	}
+	return p
+}
+
+// ClassColors returns nClasses colours from the palette. If the palette has a scheme for nClasses, its colours
+// are returned. Otherwise, sequential and diverging palettes are sampled evenly from the first to the last colour,
+// interpolating between them, so any number of classes can be coloured. Qualitative palettes return their first
+// nClasses colours, or ErrTooManyClasses if they have fewer colours than that.
+func (p Palette) ClassColors(nClasses int) ([]color.RGBA, error) {
+	if nClasses < 1 || len(p.Colors) == 0 {
+		return []color.RGBA{}, nil
+	}
+	if scheme, ok := p.Schemes[nClasses]; ok {
+		return append([]color.RGBA(nil), scheme...), nil
+	}
+
+	if p.Kind == Qualitative {
+		if nClasses > len(p.Colors) {
+			return nil, ErrTooManyClasses
+		}
+		return append([]color.RGBA(nil), p.Colors[:nClasses]...), nil
+	}
+	colors := make([]color.RGBA, nClasses)
+	for i := range colors {
+		t := 0.5
+		if nClasses > 1 {
+			t = float64(i) / float64(nClasses-1)
+		}
+		colors[i] = p.interpolate(t)
+	}
+	return colors, nil
+}
+
+// interpolate returns the colour at position t (from 0 to 1) along the ramp of colours.
+func (p Palette) interpolate(t float64) color.RGBA {
+	pos := t * float64(len(p.Colors)-1)
+	i := int(math.Floor(pos))
+	if i >= len(p.Colors)-1 {
+		return p.Colors[len(p.Colors)-1]
+	}
+	from, to, f := p.Colors[i], p.Colors[i+1], pos-float64(i)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + f*(float64(b)-float64(a))))
+	}
+	return color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: mix(from.A, to.A)}
+}
+
+// HexColor formats the colour as "#rrggbb", or "#rrggbbaa" if it isn't opaque.
+func HexColor(c color.RGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// ParseHexColor parses a colour formatted as "#rrggbb" or "#rrggbbaa" (see HexColor).
+func ParseHexColor(s string) (color.RGBA, error) {
+	if (len(s) != 7 && len(s) != 9) || s[0] != '#' {
+		return color.RGBA{}, fmt.Errorf("jenks: invalid hex colour %q", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("jenks: invalid hex colour %q", s)
+	}
+	if len(s) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.RGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// ColorScale maps values to the colour of the class containing them.
+type ColorScale struct {
+	// Classifier assigns values to classes.
+	Classifier *Classifier
+	// Colors holds the colour of each class.
+	Colors []color.RGBA
+	// Unclassified is the colour of values that aren't in any class (see Classifier.Classify) - transparent by default.
+	Unclassified color.RGBA
+}
+
+// ColorScale returns a ColorScale that colours each class of the classification with a colour from the palette,
+// or ErrTooManyClasses if a qualitative palette has too few colours for the classes (see Palette.ClassColors).
+func (c *Classification) ColorScale(p Palette) (*ColorScale, error) {
+	colors, err := p.ClassColors(len(c.Classes))
+	if err != nil {
+		return nil, err
+	}
+	return &ColorScale{
+		Classifier: c.Classifier(),
+		Colors:     colors,
+	}, nil
+}
+
+// Color returns the colour of the class containing x.
+func (s *ColorScale) Color(x float64) color.RGBA {
+	if class := s.Classifier.Classify(x); class >= 0 && class < len(s.Colors) {
+		return s.Colors[class]
+	}
+	return s.Unclassified
+}
+
+// Hex returns the colour of the class containing x, formatted by HexColor.
+func (s *ColorScale) Hex(x float64) string {
+	return HexColor(s.Color(x))
+}
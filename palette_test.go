@@ -0,0 +1,121 @@
+package jenks
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestPalette_ClassColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		palette  Palette
+		nClasses int
+		want     []string
+		wantErr  error
+	}{
+		{name: "largest scheme", palette: Blues, nClasses: 9,
+			want: []string{"#f7fbff", "#deebf7", "#c6dbef", "#9ecae1", "#6baed6", "#4292c6", "#2171b5", "#08519c", "#08306b"}},
+		{name: "scheme", palette: Blues, nClasses: 3,
+			want: []string{"#deebf7", "#9ecae1", "#3182bd"}},
+		{name: "diverging scheme", palette: RdBu, nClasses: 5,
+			want: []string{"#ca0020", "#f4a582", "#f7f7f7", "#92c5de", "#0571b0"}},
+		{name: "interpolated", palette: Greys, nClasses: 2,
+			want: []string{"#ffffff", "#000000"}},
+		{name: "more classes than colours", palette: Greys, nClasses: 17,
+			want: []string{"#ffffff", "#f8f8f8", "#f0f0f0", "#e5e5e5", "#d9d9d9", "#cbcbcb", "#bdbdbd", "#aaaaaa", "#969696",
+				"#858585", "#737373", "#636363", "#525252", "#3c3c3c", "#252525", "#131313", "#000000"}},
+		{name: "single class", palette: RdBu, nClasses: 1,
+			want: []string{"#f7f7f7"}},
+		{name: "qualitative", palette: Dark2, nClasses: 3,
+			want: []string{"#1b9e77", "#d95f02", "#7570b3"}},
+		{name: "qualitative every colour", palette: Dark2, nClasses: 8,
+			want: []string{"#1b9e77", "#d95f02", "#7570b3", "#e7298a", "#66a61e", "#e6ab02", "#a6761d", "#666666"}},
+		{name: "qualitative too many classes", palette: Dark2, nClasses: 9,
+			wantErr: ErrTooManyClasses},
+		{name: "no classes", palette: Blues, nClasses: 0,
+			want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colors, err := tt.palette.ClassColors(tt.nClasses)
+			if err != tt.wantErr {
+				t.Fatalf("ClassColors(%d) error = %v, want %v", tt.nClasses, err, tt.wantErr)
+			}
+			var got []string
+			for _, c := range colors {
+				got = append(got, HexColor(c))
+			}
+			if colors != nil && got == nil {
+				got = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClassColors(%d) = %v, want %v", tt.nClasses, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPalettes(t *testing.T) {
+	for _, p := range Palettes {
+		maxClasses := 12
+		if p.Kind == Qualitative {
+			maxClasses = len(p.Colors)
+		}
+		for nClasses := 2; nClasses <= maxClasses; nClasses++ {
+			if got, err := p.ClassColors(nClasses); err != nil || len(got) != nClasses {
+				t.Errorf("%s.ClassColors(%d) returned %d colours, error %v", p.Name, nClasses, len(got), err)
+			}
+		}
+		for nClasses, scheme := range p.Schemes {
+			if len(scheme) != nClasses {
+				t.Errorf("%s has %d colours in its scheme for %d classes", p.Name, len(scheme), nClasses)
+			}
+		}
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		hex     string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{hex: "#08306b", want: color.RGBA{R: 0x08, G: 0x30, B: 0x6b, A: 0xff}},
+		{hex: "#08306b80", want: color.RGBA{R: 0x08, G: 0x30, B: 0x6b, A: 0x80}},
+		{hex: "08306b", wantErr: true},
+		{hex: "#08306", wantErr: true},
+		{hex: "#08306x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHexColor(tt.hex)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseHexColor(%q) = %v, %v, want %v", tt.hex, got, err, tt.want)
+		}
+		if err == nil && HexColor(got) != tt.hex {
+			t.Errorf("HexColor(%v) = %q, want %q", got, HexColor(got), tt.hex)
+		}
+	}
+}
+
+func TestClassification_ColorScale(t *testing.T) {
+	data := []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 21.1, 22.1, 23.1, 27.1, 28.1, 29.1}
+	scale, err := NaturalClassification(data, 4).ColorScale(Blues)
+	if err != nil {
+		t.Fatalf("ColorScale() error = %v", err)
+	}
+
+	want := map[float64]string{1.1: "#eff3ff", 13: "#bdd7e7", 22.1: "#6baed6", 29.1: "#2171b5", 0: "#00000000", 30: "#00000000"}
+	for v, hex := range want {
+		if got := scale.Hex(v); got != hex {
+			t.Errorf("Hex(%v) = %v, want %v", v, got, hex)
+		}
+	}
+}
+
+func TestClassification_ColorScaleTooManyClasses(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if _, err := NaturalClassification(data, 9).ColorScale(Dark2); err != ErrTooManyClasses {
+		t.Errorf("ColorScale() error = %v, want %v", err, ErrTooManyClasses)
+	}
+}
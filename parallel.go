@@ -0,0 +1,60 @@
+package jenks
+
+import "sync"
+
+// parallelChunk is the number of consecutive cells computed by a goroutine at a time. The cost of each cell grows
+// with its index, so the chunks are interleaved between the goroutines to share the work evenly.
+const parallelChunk = 64
+
+// parallelCells calls cell with every index from 0 to n-1, using the given number of goroutines.
+// If workers is 1 or less, the cells are computed in order by the calling goroutine.
+func parallelCells(n, workers int, cell func(l int)) {
+	if workers <= 1 {
+		for l := 0; l < n; l++ {
+			cell(l)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for start := w * parallelChunk; start < n; start += workers * parallelChunk {
+				for l := start; l < start+parallelChunk && l < n; l++ {
+					cell(l)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// getParallelMatrices computes the same matrices as getWeightedMatrices, using the given number of goroutines.
+// Each cell of the matrices depends only on the cells for one fewer class, so the cells for each number of classes
+// are computed concurrently (using varianceCell, which performs exactly the same arithmetic), one class at a time.
+func getParallelMatrices(data []float64, weights []float64, nClasses, workers int) ([]int, []float64) {
+	if workers <= 1 {
+		return getWeightedMatrices(data, weights, nClasses)
+	}
+
+	x := len(data) + 1
+	y := nClasses + 1
+	lowerClassLimits := make([]int, mat2len(x, y))
+	varianceCombinations := make([]float64, mat2len(x, y))
+
+	// the matrices are stored by data point, so the cells for each number of classes are also kept in a row of their own
+	var prev []float64
+	row, spare := make([]float64, x), make([]float64, x)
+	for j := 1; j < y; j++ {
+		parallelCells(x, workers, func(l int) {
+			variance, lowerClassLimit := varianceCell(data, weights, prev, l)
+			row[l] = variance
+			lowerClassLimits[mat2idx(l, j, y)] = lowerClassLimit
+			varianceCombinations[mat2idx(l, j, y)] = variance
+		})
+		prev, row, spare = row, spare, row
+	}
+	return lowerClassLimits, varianceCombinations
+}
@@ -0,0 +1,44 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetParallelMatrices(t *testing.T) {
+	weights := make([]float64, len(benchmarkData))
+	for i := range weights {
+		weights[i] = float64(1 + i%7)
+	}
+
+	for _, workers := range []int{2, 3, 8} {
+		for _, nClasses := range []int{1, 2, 5, 10} {
+			wantLimits, wantVariances := getMatrices(benchmarkData, nClasses)
+			limits, variances := getParallelMatrices(benchmarkData, nil, nClasses, workers)
+			if !reflect.DeepEqual(limits, wantLimits) || !reflect.DeepEqual(variances, wantVariances) {
+				t.Errorf("getParallelMatrices(%d, %d) differs from getMatrices", nClasses, workers)
+			}
+
+			wantLimits, wantVariances = getWeightedMatrices(benchmarkData, weights, nClasses)
+			limits, variances = getParallelMatrices(benchmarkData, weights, nClasses, workers)
+			if !reflect.DeepEqual(limits, wantLimits) || !reflect.DeepEqual(variances, wantVariances) {
+				t.Errorf("getParallelMatrices(%d, %d) differs from getWeightedMatrices", nClasses, workers)
+			}
+		}
+	}
+}
+
+func TestNaturalBreaksWithOptions_Workers(t *testing.T) {
+	for _, method := range []Method{MethodMatrix, MethodLinearMemory} {
+		want, _ := NaturalBreaksWithOptions(largeBenchmarkData, 7, Options{Method: method})
+		for _, workers := range []int{2, 5} {
+			got, err := NaturalBreaksWithOptions(largeBenchmarkData, 7, Options{Method: method, Workers: workers})
+			if err != nil {
+				t.Fatalf("NaturalBreaksWithOptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("NaturalBreaksWithOptions(method %d, %d workers) = %v, want %v", method, workers, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+// pinnedClassification classifies the sorted data into nClasses, with a break at each of the pinned values in the options.
+//
+// The data is split into segments at the pinned values. Each segment is classified for every number of classes it
+// could be given, then the classes are allocated between the segments by dynamic programming: the minimum
+// sum of squared deviations from the class means of the first s segments using j classes is the minimum, over the
+// classes k given to segment s, of the minimum for the first s-1 segments using j-k classes plus that of segment s with k.
+func pinnedClassification(data []float64, nClasses int, opts Options) (*Classification, error) {
+	starts, lowers := pinnedSegments(data, opts.Pinned)
+	if nClasses < len(starts) {
+		nClasses = len(starts)
+	}
+	segmentOpts := opts
+	segmentOpts.Pinned = nil
+
+	// classifications[s][k-1] is the classification of segment s into k classes
+	classifications := make([][]*Classification, len(starts))
+	for s, start := range starts {
+		end := len(data)
+		if s+1 < len(starts) {
+			end = starts[s+1]
+		}
+		all, err := segmentClassifications(data[start:end], nClasses-len(starts)+1, segmentOpts)
+		if err != nil {
+			return nil, err
+		}
+		classifications[s] = all
+	}
+
+	// sdcm[j] is the minimum sum of squared deviations from the class means of the segments so far, using j classes,
+	// and choices[s][j] is the number of classes given to segment s to achieve it
+	sdcm := make([]float64, nClasses+1)
+	next := make([]float64, nClasses+1)
+	choices := make([][]int, len(starts))
+	for j := range sdcm {
+		sdcm[j] = math.Inf(+1)
+	}
+	sdcm[0] = 0
+	for s, all := range classifications {
+		choices[s] = make([]int, nClasses+1)
+		for j := range next {
+			next[j] = math.Inf(+1)
+			for k := 1; k <= len(all) && k <= j; k++ {
+				// choose the fewest classes for this segment on ties
+				if v := sdcm[j-k] + classificationSDCM(all[k-1]); v < next[j] {
+					next[j] = v
+					choices[s][j] = k
+				}
+			}
+		}
+		sdcm, next = next, sdcm
+	}
+
+	// use as many classes as possible, up to nClasses
+	j := nClasses
+	for math.IsInf(sdcm[j], +1) {
+		j--
+	}
+
+	segmentBoundaries := make([][]int, len(starts))
+	for s := len(starts) - 1; s >= 0; s-- {
+		k := choices[s][j]
+		for _, class := range classifications[s][k-1].Classes {
+			segmentBoundaries[s] = append(segmentBoundaries[s], starts[s]+class.Start)
+		}
+		j -= k
+	}
+
+	boundaries := []int{}
+	for _, b := range segmentBoundaries {
+		boundaries = append(boundaries, b...)
+	}
+	c := newClassification(data, nil, boundaries)
+
+	// the lower bound of the first class in each segment is the pinned value, rather than the lowest value in the class
+	class := 0
+	for s, b := range segmentBoundaries {
+		c.Classes[class].Lower = lowers[s]
+		class += len(b)
+	}
+	return c, nil
+}
+
+// pinnedSegments splits the sorted data at the pinned values, returning the index of the first data point in each segment,
+// and the lower bound of each segment: the pinned value, or the minimum of the data for the first segment.
+// Pinned values that would leave a segment empty are ignored.
+func pinnedSegments(data []float64, pinned []float64) ([]int, []float64) {
+	starts := []int{0}
+	lowers := []float64{data[0]}
+	for _, p := range sortData(pinned) {
+		i := sort.SearchFloat64s(data, p)
+		if i > starts[len(starts)-1] && i < len(data) {
+			starts = append(starts, i)
+			lowers = append(lowers, p)
+		}
+	}
+	return starts, lowers
+}
+
+// segmentClassifications returns the classifications of the sorted data into 1 to maxClasses classes
+// (capped at the number of unique values), using the options.
+func segmentClassifications(data []float64, maxClasses int, opts Options) ([]*Classification, error) {
+	if uniq := countUniqueValues(data); maxClasses > uniq {
+		maxClasses = uniq
+	}
+	if !opts.constrained() && !opts.Stable && opts.method(len(data), maxClasses) == MethodMatrix && matrixFits(len(data), maxClasses) {
+		// the matrices for maxClasses hold the classifications for every smaller number of classes
+		return naturalClassifications(data, 1, maxClasses), nil
+	}
+
+	all := make([]*Classification, maxClasses)
+	for k := range all {
+		c, err := optionsClassification(data, k+1, opts)
+		if err != nil {
+			return nil, err
+		}
+		all[k] = c
+	}
+	return all, nil
+}
+
+// classificationSDCM returns the sum of squared deviations from the class means of the classification.
+func classificationSDCM(c *Classification) float64 {
+	sdcm := 0.0
+	for _, class := range c.Classes {
+		sdcm += class.Variance * class.Weight
+	}
+	return sdcm
+}
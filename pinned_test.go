@@ -0,0 +1,98 @@
+package jenks
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPinnedNaturalBreaks(t *testing.T) {
+	data := []float64{-9, -8, -7, -2, -1, 1, 2, 3, 12, 13, 14, 40, 41, 42, 48, 49, 51, 52, 60}
+
+	tests := []struct {
+		name     string
+		nClasses int
+		pinned   []float64
+		want     []float64
+		wantErr  error
+	}{
+		{name: "unpinned",
+			nClasses: 4, pinned: nil,
+			want: []float64{-9, 12, 40, 48}},
+		{name: "pinned at zero",
+			nClasses: 4, pinned: []float64{0},
+			want: []float64{-9, 0, 12, 40}},
+		{name: "pinned between data points",
+			nClasses: 5, pinned: []float64{0, 50},
+			want: []float64{-9, 0, 12, 40, 50}},
+		{name: "pinned at a data point",
+			nClasses: 5, pinned: []float64{0, 49},
+			want: []float64{-9, 0, 12, 40, 49}},
+		{name: "more segments than classes",
+			nClasses: 2, pinned: []float64{50, 0},
+			want: []float64{-9, 0, 50}},
+		{name: "pinned outside the data",
+			nClasses: 4, pinned: []float64{-9, -100, 61},
+			want: []float64{-9, 12, 40, 48}},
+		{name: "NaN",
+			nClasses: 4, pinned: []float64{math.NaN()},
+			wantErr: ErrNaN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NaturalBreaksWithOptions(data, tt.nClasses, Options{Pinned: tt.pinned})
+			if err != tt.wantErr {
+				t.Fatalf("NaturalBreaksWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NaturalBreaksWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPinnedNaturalBreaksAllocatesClasses(t *testing.T) {
+	random := rand.New(rand.NewSource(13))
+	for trial := 0; trial < 20; trial++ {
+		data := make([]float64, 30)
+		for i := range data {
+			data[i] = random.NormFloat64() * 10
+		}
+		// pin two values between data points, so that each segment holds some data
+		sorted := sortData(data)
+		i := 1 + random.Intn(len(data)/2-1)
+		j := len(data)/2 + random.Intn(len(data)/2-1)
+		pinned := []float64{(sorted[j] + sorted[j+1]) / 2, (sorted[i-1] + sorted[i]) / 2}
+		nClasses := 3 + random.Intn(5)
+
+		got, err := NaturalClassificationWithOptions(data, nClasses, Options{Pinned: pinned})
+		if err != nil {
+			t.Fatalf("NaturalClassificationWithOptions() error = %v", err)
+		}
+		if len(got.Classes) != nClasses {
+			t.Fatalf("NaturalClassificationWithOptions(%d) has %d classes", nClasses, len(got.Classes))
+		}
+		for _, p := range pinned {
+			if i := sort.SearchFloat64s(got.Breaks(), p); i == len(got.Classes) || got.Classes[i].Lower != p {
+				t.Errorf("NaturalClassificationWithOptions() = %v, want a break at %v", got.Breaks(), p)
+			}
+		}
+
+		// try every allocation of classes to the three segments
+		segments := [][]float64{sorted[:i], sorted[i : j+1], sorted[j+1:]}
+		best := math.Inf(+1)
+		for k0 := 1; k0 <= nClasses-2; k0++ {
+			for k1 := 1; k0+k1 <= nClasses-1; k1++ {
+				sdcm := classificationSDCM(NaturalClassification(segments[0], k0)) +
+					classificationSDCM(NaturalClassification(segments[1], k1)) +
+					classificationSDCM(NaturalClassification(segments[2], nClasses-k0-k1))
+				best = math.Min(best, sdcm)
+			}
+		}
+		if sdcm := classificationSDCM(got); math.Abs(sdcm-best) > 1e-9 {
+			t.Errorf("NaturalClassificationWithOptions(%d) SDCM = %v, want %v", nClasses, sdcm, best)
+		}
+	}
+}
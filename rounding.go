@@ -0,0 +1,128 @@
+package jenks
+
+import "math"
+
+// RoundMode selects how RoundWithOptions rounds breaks.
+type RoundMode int
+
+const (
+	// RoundFewestDigits rounds each break down as far as possible, to a whole number of the largest power of ten
+	// that doesn't change the membership of any class - much as Round does.
+	RoundFewestDigits RoundMode = iota
+	// RoundSignificantFigures rounds each break down to RoundOptions.Digits significant figures.
+	RoundSignificantFigures
+	// RoundDecimalPlaces rounds every break down to RoundOptions.Digits decimal places
+	// (which may be negative - e.g. -2 rounds to a whole number of hundreds).
+	RoundDecimalPlaces
+	// RoundSameMagnitude rounds every break down to the same number of decimal places:
+	// the fewest that don't change the membership of any class.
+	RoundSameMagnitude
+)
+
+// RoundOptions configures RoundWithOptions.
+type RoundOptions struct {
+	// Mode selects how the breaks are rounded.
+	Mode RoundMode
+	// Digits is the number of significant figures or decimal places, for RoundSignificantFigures and RoundDecimalPlaces.
+	Digits int
+}
+
+// RoundWithOptions rounds the breaks for display, without changing the membership of any class of the data.
+// Where a break can't be rounded to the requested precision without changing the membership of a class,
+// it is rounded as far as it can be (which may be not at all), and its index is included in unrounded.
+func RoundWithOptions(breaks []float64, data []float64, opts RoundOptions) (rounded []float64, unrounded []int) {
+	data = sortData(data)
+	rounded = make([]float64, len(breaks))
+	unrounded = []int{}
+
+	floors := make([]float64, len(breaks))
+	for i := range breaks {
+		floors[i] = roundingFloor(breaks, data, i)
+	}
+
+	if opts.Mode == RoundSameMagnitude {
+		// the common number of decimal places is the most needed by any break
+		decimalPlaces, found := 0, false
+		for i, b := range breaks {
+			if d, ok := fewestDecimalPlaces(b, floors[i]); ok && (!found || d > decimalPlaces) {
+				decimalPlaces, found = d, true
+			}
+		}
+		for i, b := range breaks {
+			if r := roundDecimalPlaces(b, decimalPlaces); found && r > floors[i] {
+				rounded[i] = r
+			} else {
+				rounded[i] = b
+				unrounded = append(unrounded, i)
+			}
+		}
+		return rounded, unrounded
+	}
+
+	for i, b := range breaks {
+		var r float64
+		switch opts.Mode {
+		case RoundSignificantFigures:
+			r = roundDecimalPlaces(b, opts.Digits-1-magnitude(b))
+		case RoundDecimalPlaces:
+			r = roundDecimalPlaces(b, opts.Digits)
+		default:
+			r = math.Inf(-1)
+			if d, ok := fewestDecimalPlaces(b, floors[i]); ok {
+				r = roundDecimalPlaces(b, d)
+			}
+		}
+		if r > floors[i] {
+			rounded[i] = r
+			continue
+		}
+
+		// round as far as possible instead
+		rounded[i] = b
+		if d, ok := fewestDecimalPlaces(b, floors[i]); ok {
+			rounded[i] = roundDecimalPlaces(b, d)
+		}
+		unrounded = append(unrounded, i)
+	}
+	return rounded, unrounded
+}
+
+// fewestDecimalPlaces returns the fewest decimal places (possibly negative) that value can be rounded down to
+// while remaining above the floor, or false if there are none.
+func fewestDecimalPlaces(value float64, floor float64) (int, bool) {
+	if !(value > floor) || math.IsInf(value, 0) {
+		return 0, false
+	}
+
+	// rounding to a power of ten greater than both the value and the floor gives zero, or less;
+	// rounding to one no greater than value-floor can't reach the floor
+	d := -magnitude(math.Max(math.Abs(value), math.Abs(floor))) - 2
+	last := -magnitude(value-floor) + 1
+	for ; d <= last; d++ {
+		if r := roundDecimalPlaces(value, d); r > floor {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// roundDecimalPlaces rounds value down to the given number of decimal places (possibly negative).
+func roundDecimalPlaces(value float64, decimalPlaces int) float64 {
+	return floorToStep(value, 1, -decimalPlaces)
+}
+
+// magnitude returns the power of ten of the most significant digit of value: e.g. 2 for 123.4, or -2 for 0.0123.
+func magnitude(value float64) int {
+	if value == 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0
+	}
+	value = math.Abs(value)
+	m := int(math.Floor(math.Log10(value)))
+	// math.Log10 isn't exact, e.g. for 1000
+	if math.Pow10(m+1) <= value {
+		m++
+	} else if math.Pow10(m) > value {
+		m--
+	}
+	return m
+}
@@ -0,0 +1,62 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundWithOptions(t *testing.T) {
+	data := []float64{0.31, 4.2, 5.7, 12.34, 13.1, 26.5, 27.13, 27.2, 1234.5, 1300}
+	breaks := []float64{0.31, 12.34, 27.13, 1234.5}
+
+	tests := []struct {
+		name          string
+		opts          RoundOptions
+		want          []float64
+		wantUnrounded []int
+	}{
+		{name: "fewest digits",
+			opts:          RoundOptions{},
+			want:          []float64{0, 10, 27, 1000},
+			wantUnrounded: []int{}},
+		{name: "significant figures",
+			opts:          RoundOptions{Mode: RoundSignificantFigures, Digits: 2},
+			want:          []float64{0.31, 12, 27, 1200},
+			wantUnrounded: []int{}},
+		{name: "too few significant figures",
+			opts:          RoundOptions{Mode: RoundSignificantFigures, Digits: 1},
+			want:          []float64{0.3, 10, 27, 1000},
+			wantUnrounded: []int{2}},
+		{name: "decimal places",
+			opts:          RoundOptions{Mode: RoundDecimalPlaces, Digits: 1},
+			want:          []float64{0.3, 12.3, 27.1, 1234.5},
+			wantUnrounded: []int{}},
+		{name: "too few decimal places",
+			opts:          RoundOptions{Mode: RoundDecimalPlaces, Digits: -1},
+			want:          []float64{0, 10, 27, 1230},
+			wantUnrounded: []int{2}},
+		{name: "same magnitude",
+			opts:          RoundOptions{Mode: RoundSameMagnitude},
+			want:          []float64{0, 12, 27, 1234},
+			wantUnrounded: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unrounded := RoundWithOptions(breaks, data, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(unrounded, tt.wantUnrounded) {
+				t.Errorf("RoundWithOptions() = %v, %v, want %v, %v", got, unrounded, tt.want, tt.wantUnrounded)
+			}
+			if !reflect.DeepEqual(NewClassifier(got, 1e9).ClassifyAll(data), NewClassifier(breaks, 1e9).ClassifyAll(data)) {
+				t.Errorf("RoundWithOptions() = %v changes the membership of the classes of %v", got, breaks)
+			}
+		})
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	for v, want := range map[float64]int{1000: 3, 999.9: 2, 0.001: -3, 0.0123: -2, -123.4: 2, 0: 0} {
+		if got := magnitude(v); got != want {
+			t.Errorf("magnitude(%v) = %v, want %v", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package jenks
+
+import "math"
+
+// Criterion chooses the number of classes, given the natural breaks classification of the same data for 1, 2, ... classes
+// (where classifications[k-1] has k classes). It returns the chosen number of classes.
+type Criterion func(classifications []*Classification) int
+
+// Selection is the result of SelectNaturalBreaks.
+type Selection struct {
+	// NClasses is the chosen number of classes.
+	NClasses int
+	// Classification is the natural breaks classification of the data into NClasses classes.
+	Classification *Classification
+	// GVF holds the goodness of variance fit for every number of classes considered: GVF[k-1] is for k classes.
+	GVF []float64
+}
+
+// SelectNaturalBreaks computes the natural breaks of the data for every number of classes up to maxClasses,
+// and chooses between them using the given criterion - e.g. ElbowCriterion, BICCriterion, AICCriterion or
+// MarginalGainCriterion. Unlike BestNaturalBreaks, it doesn't need a fixed minimum goodness of variance fit.
+// If the data is empty or maxClasses is less than 1, no classes are chosen.
+func SelectNaturalBreaks(data []float64, maxClasses int, criterion Criterion) *Selection {
+	data = sortData(data)
+	var classifications []*Classification
+	if maxClasses >= 1 {
+		classifications = naturalClassifications(data, 1, maxClasses)
+	}
+	if len(classifications) == 0 {
+		return &Selection{Classification: &Classification{Data: data}, GVF: []float64{}}
+	}
+
+	s := &Selection{GVF: make([]float64, len(classifications))}
+	for i, c := range classifications {
+		s.GVF[i] = c.GVF
+	}
+
+	s.NClasses = criterion(classifications)
+	if s.NClasses < 1 {
+		s.NClasses = 1
+	} else if s.NClasses > len(classifications) {
+		s.NClasses = len(classifications)
+	}
+	s.Classification = classifications[s.NClasses-1]
+	return s
+}
+
+// ElbowCriterion chooses the number of classes at the 'elbow' (or knee) of the goodness of variance fit curve:
+// the point furthest above the straight line joining the first and last points of the curve,
+// beyond which adding classes yields diminishing returns.
+func ElbowCriterion() Criterion {
+	return func(classifications []*Classification) int {
+		n := len(classifications)
+		if n < 3 {
+			return n
+		}
+
+		first, last := classifications[0].GVF, classifications[n-1].GVF
+		best, bestDistance := n, 0.0
+		for i, c := range classifications {
+			// the vertical distance above the line is proportional to the perpendicular distance from it
+			line := first + (last-first)*float64(i)/float64(n-1)
+			if distance := c.GVF - line; distance > bestDistance {
+				best, bestDistance = i+1, distance
+			}
+		}
+		return best
+	}
+}
+
+// MarginalGainCriterion chooses the smallest number of classes for which adding another class
+// would improve the goodness of variance fit by less than minGain.
+func MarginalGainCriterion(minGain float64) Criterion {
+	return func(classifications []*Classification) int {
+		for i := 1; i < len(classifications); i++ {
+			if classifications[i].GVF-classifications[i-1].GVF < minGain {
+				return i
+			}
+		}
+		return len(classifications)
+	}
+}
+
+// BICCriterion chooses the number of classes that minimises the Bayesian information criterion,
+// modelling the data as a mixture of one normal distribution per class, with a common variance.
+func BICCriterion() Criterion {
+	return func(classifications []*Classification) int {
+		return minimiseInformationCriterion(classifications, func(parameters, n float64) float64 {
+			return parameters * math.Log(n)
+		})
+	}
+}
+
+// AICCriterion chooses the number of classes that minimises the Akaike information criterion,
+// modelling the data as a mixture of one normal distribution per class, with a common variance.
+// It tends to choose more classes than BICCriterion.
+func AICCriterion() Criterion {
+	return func(classifications []*Classification) int {
+		return minimiseInformationCriterion(classifications, func(parameters, n float64) float64 {
+			return 2 * parameters
+		})
+	}
+}
+
+// minimiseInformationCriterion returns the number of classes minimising -2 * log-likelihood + penalty(parameters, n).
+func minimiseInformationCriterion(classifications []*Classification, penalty func(parameters, n float64) float64) int {
+	best, bestScore := 1, math.Inf(1)
+	for i, c := range classifications {
+		k := float64(i + 1)
+		// k-1 mixing proportions, k means and a common variance
+		if score := -2*logLikelihood(c) + penalty(2*k, totalWeight(c)); score < bestScore {
+			best, bestScore = i+1, score
+		}
+	}
+	return best
+}
+
+// logLikelihood returns the log-likelihood of the data, modelled as a mixture of normal distributions
+// (one for each class, with the mean of the class, weighted by its size, and a common variance).
+func logLikelihood(c *Classification) float64 {
+	n := totalWeight(c)
+	sdcm := 0.0
+	ll := 0.0
+	for _, class := range c.Classes {
+		if class.Weight > 0 {
+			ll += class.Weight * math.Log(class.Weight/n)
+		}
+		sdcm += class.Variance * class.Weight
+	}
+	variance := sdcm / n
+	if variance == 0 {
+		// a perfect fit
+		return math.Inf(1)
+	}
+	return ll - n/2*math.Log(2*math.Pi*variance) - n/2
+}
+
+func totalWeight(c *Classification) float64 {
+	n := 0.0
+	for _, class := range c.Classes {
+		n += class.Weight
+	}
+	return n
+}
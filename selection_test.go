@@ -0,0 +1,79 @@
+package jenks
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func clusteredData(centres []float64, perCluster int, spread float64) []float64 {
+	random := rand.New(rand.NewSource(11))
+	data := []float64{}
+	for _, c := range centres {
+		for i := 0; i < perCluster; i++ {
+			data = append(data, c+random.NormFloat64()*spread)
+		}
+	}
+	return data
+}
+
+func TestSelectNaturalBreaks(t *testing.T) {
+	data := clusteredData([]float64{0, 50, 100}, 40, 3)
+
+	tests := []struct {
+		name      string
+		criterion Criterion
+		want      int
+	}{
+		{name: "elbow", criterion: ElbowCriterion(), want: 3},
+		{name: "BIC", criterion: BICCriterion(), want: 3},
+		{name: "AIC", criterion: AICCriterion(), want: 3},
+		{name: "marginal gain", criterion: MarginalGainCriterion(0.01), want: 3},
+		{name: "custom", criterion: func([]*Classification) int { return 5 }, want: 5},
+		{name: "out of range", criterion: func([]*Classification) int { return 20 }, want: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SelectNaturalBreaks(data, 8, tt.criterion)
+			if got.NClasses != tt.want {
+				t.Errorf("SelectNaturalBreaks().NClasses = %v, want %v (GVF %v)", got.NClasses, tt.want, got.GVF)
+			}
+			if len(got.GVF) != 8 {
+				t.Errorf("len(SelectNaturalBreaks().GVF) = %v, want 8", len(got.GVF))
+			}
+			if !reflect.DeepEqual(got.Classification.Breaks(), NaturalBreaks(data, tt.want)) {
+				t.Errorf("SelectNaturalBreaks().Classification.Breaks() = %v, want %v", got.Classification.Breaks(), NaturalBreaks(data, tt.want))
+			}
+		})
+	}
+}
+
+func TestSelectNaturalBreaksGVFCurve(t *testing.T) {
+	got := SelectNaturalBreaks(benchmarkData, 6, MarginalGainCriterion(0))
+	if got.GVF[0] != 0 {
+		t.Errorf("SelectNaturalBreaks().GVF[0] = %v, want 0", got.GVF[0])
+	}
+	for i, c := range AllNaturalClassifications(benchmarkData, 6) {
+		if got.GVF[i+1] != c.GVF {
+			t.Errorf("SelectNaturalBreaks().GVF[%d] = %v, want %v", i+1, got.GVF[i+1], c.GVF)
+		}
+	}
+}
+
+func TestSelectNaturalBreaksEdgeCases(t *testing.T) {
+	if got := SelectNaturalBreaks([]float64{}, 5, BICCriterion()); got.NClasses != 0 || len(got.GVF) != 0 {
+		t.Errorf("SelectNaturalBreaks(empty) = %+v, want no classes", got)
+	}
+	for _, maxClasses := range []int{0, -1} {
+		if got := SelectNaturalBreaks([]float64{1, 2, 3}, maxClasses, BICCriterion()); got.NClasses != 0 || len(got.GVF) != 0 {
+			t.Errorf("SelectNaturalBreaks(maxClasses %d) = %+v, want no classes", maxClasses, got)
+		}
+	}
+	if got := SelectNaturalBreaks([]float64{3, 3, 3}, 5, ElbowCriterion()); got.NClasses != 1 {
+		t.Errorf("SelectNaturalBreaks(one unique value).NClasses = %v, want 1", got.NClasses)
+	}
+	// a perfect fit is always chosen by the information criteria
+	if got := SelectNaturalBreaks([]float64{1, 1, 5, 5, 9, 9}, 5, BICCriterion()); got.NClasses != 3 {
+		t.Errorf("SelectNaturalBreaks(three unique values).NClasses = %v, want 3", got.NClasses)
+	}
+}
@@ -0,0 +1,63 @@
+package jenks
+
+import "math"
+
+// stableBoundaries computes the class boundaries that forEachBreak derives from getWeightedMatrices(data, weights, nClasses),
+// walking back from the class ending at data point 'last' (counting from 1), but with the variance of each candidate class
+// accumulated using Welford's method rather than as sumSquares - sum*sum/w. The latter loses all precision when the values
+// are large relative to their spread (e.g. timestamps), as the two terms are almost equal.
+func stableBoundaries(data []float64, weights []float64, last, nClasses int) []int {
+	x := len(data) + 1
+	y := nClasses + 1
+	lowerClassLimits := make([]int, mat2len(x, y))
+	varianceCombinations := make([]float64, mat2len(x, y))
+
+	for i := 1; i < y; i++ {
+		lowerClassLimits[mat2idx(1, i, y)] = 1
+		for j := 2; j < x; j++ {
+			varianceCombinations[mat2idx(j, i, y)] = math.Inf(+1)
+		}
+	}
+
+	for l := 2; l < x; l++ {
+		i1 := mat2idx(l, 0, y)
+
+		// the weight, mean and sum of squared deviations from the mean of data[lowerClassLimit-1:l]
+		w, mean, variance := 0.0, 0.0, 0.0
+
+		for m := 1; m < l+1; m++ {
+			lowerClassLimit := l - m + 1
+			currentIndex := lowerClassLimit - 1
+			val := data[currentIndex]
+
+			wt := 1.0
+			if weights != nil {
+				wt = weights[currentIndex]
+			}
+			w += wt
+			delta := val - mean
+			mean += wt * delta / w
+			variance += wt * delta * (val - mean)
+
+			if currentIndex != 0 {
+				i2 := mat2idx(currentIndex, 0, y)
+				for j := 2; j < y; j++ {
+					j1 := i1 + j
+					if v := varianceCombinations[i2+j-1] + variance; varianceCombinations[j1] >= v {
+						lowerClassLimits[j1] = lowerClassLimit
+						varianceCombinations[j1] = v
+					}
+				}
+			}
+		}
+
+		lowerClassLimits[i1+1] = 1
+		varianceCombinations[i1+1] = variance
+	}
+
+	boundaries := make([]int, nClasses)
+	forEachLimit(lowerClassLimits, last, nClasses, nClasses, func(class, boundary int) {
+		boundaries[class-1] = boundary
+	})
+	return boundaries
+}
@@ -0,0 +1,50 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNaturalBreaksWithOptions_Stable(t *testing.T) {
+	// epoch timestamps, in three bursts - for which NaturalBreaks returns [1.7e9+0.1, 1.7e9+0.25, 1.7e9+7.5]
+	offsets := []float64{0.1, 0.25, 0.3, 0.42, 7.5, 7.55, 7.61, 7.7, 15.02, 15.1, 15.13, 15.2, 15.31}
+	data := make([]float64, len(offsets))
+	for i, offset := range offsets {
+		data[i] = 1.7e9 + offset
+	}
+	want := []float64{1.7e9 + 0.1, 1.7e9 + 7.5, 1.7e9 + 15.02}
+
+	got, err := NaturalBreaksWithOptions(data, 3, Options{Stable: true})
+	if err != nil {
+		t.Fatalf("NaturalBreaksWithOptions() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NaturalBreaksWithOptions(Stable) = %v, want %v", got, want)
+	}
+
+	// the same breaks as for the offsets alone, where there's no loss of precision
+	for _, nClasses := range []int{2, 4, 6} {
+		got, _ := NaturalBreaksWithOptions(data, nClasses, Options{Stable: true})
+		want := NaturalBreaks(offsets, nClasses)
+		for i := range want {
+			want[i] += 1.7e9
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("NaturalBreaksWithOptions(%d, Stable) = %v, want %v", nClasses, got, want)
+		}
+	}
+}
+
+func TestStableBoundaries(t *testing.T) {
+	// with values of small magnitude, both methods compute the same breaks
+	for _, nClasses := range []int{1, 2, 5, 10} {
+		want := NaturalBreaks(benchmarkData, nClasses)
+		got, err := NaturalBreaksWithOptions(benchmarkData, nClasses, Options{Stable: true})
+		if err != nil {
+			t.Fatalf("NaturalBreaksWithOptions() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("NaturalBreaksWithOptions(%d, Stable) = %v, want %v", nClasses, got, want)
+		}
+	}
+}
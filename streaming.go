@@ -0,0 +1,179 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+// StreamingJenks maintains a bounded-memory summary of a stream of values, from which natural breaks can be computed
+// at any time, without keeping every value or recomputing from scratch.
+//
+// The summary is a histogram of at most maxBins bins. While there are no more distinct values than bins, each value
+// has a bin of its own, and the breaks are exactly those NaturalBreaks would return. Beyond that, the two adjacent bins
+// whose merged range would be narrowest are merged, and the breaks are computed from the mean and count of each bin.
+type StreamingJenks struct {
+	maxBins int
+	// bins are ordered by value, and their ranges don't overlap
+	bins []streamingBin
+	n    int
+}
+
+// streamingBin summarises the values between min and max (inclusive).
+type streamingBin struct {
+	min, max float64
+	count    int
+	// sum and sumSquares are the sum of the values, and of their squares
+	sum, sumSquares float64
+}
+
+// value returns the value representing the bin: its mean, or exactly the value in the bin if there is only one.
+func (b streamingBin) value() float64 {
+	if b.min == b.max {
+		return b.min
+	}
+	return b.sum / float64(b.count)
+}
+
+// ssd returns the sum of the squared deviations of the values in the bin from their mean.
+func (b streamingBin) ssd() float64 {
+	if b.min == b.max {
+		return 0
+	}
+	return math.Max(0, b.sumSquares-b.sum*b.sum/float64(b.count))
+}
+
+// NewStreamingJenks returns an empty StreamingJenks that summarises values using at most maxBins bins (at least 2).
+// The memory used, and the time taken to compute breaks, depend only on maxBins.
+func NewStreamingJenks(maxBins int) *StreamingJenks {
+	if maxBins < 2 {
+		maxBins = 2
+	}
+	return &StreamingJenks{maxBins: maxBins}
+}
+
+// Len returns the number of values in the summary.
+func (s *StreamingJenks) Len() int {
+	return s.n
+}
+
+// Add adds a value to the summary. NaN and infinite values are ignored.
+func (s *StreamingJenks) Add(x float64) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return
+	}
+	s.n++
+
+	i := s.search(x)
+	if i < len(s.bins) && s.bins[i].min <= x {
+		s.bins[i].count++
+		s.bins[i].sum += x
+		s.bins[i].sumSquares += x * x
+		return
+	}
+
+	s.bins = append(s.bins, streamingBin{})
+	copy(s.bins[i+1:], s.bins[i:])
+	s.bins[i] = streamingBin{min: x, max: x, count: 1, sum: x, sumSquares: x * x}
+	if len(s.bins) > s.maxBins {
+		s.merge()
+	}
+}
+
+// Remove removes a value previously added to the summary, returning false if it can't have been added because
+// it isn't within the range of any bin.
+//
+// Once a value has been merged into a bin with others, the summary no longer records whether it was added: removing
+// any value within the range of such a bin succeeds, so only values that were added should be removed.
+// The range of the bin is not narrowed.
+func (s *StreamingJenks) Remove(x float64) bool {
+	i := s.search(x)
+	if i == len(s.bins) || s.bins[i].min > x {
+		return false
+	}
+	s.n--
+
+	if s.bins[i].count--; s.bins[i].count == 0 {
+		s.bins = append(s.bins[:i], s.bins[i+1:]...)
+		return true
+	}
+	s.bins[i].sum -= x
+	s.bins[i].sumSquares -= x * x
+	return true
+}
+
+// Breaks returns the best nClasses natural breaks of the values in the summary, in the same form as NaturalBreaks.
+// Each break is the lowest value in a bin, so values in the same bin are always in the same class.
+//
+// gvfBound bounds the error of the breaks: the goodness of variance fit of the exact natural breaks of the values
+// is at most gvfBound more than the goodness of variance fit of the returned breaks. It is 0 when every bin holds
+// a single distinct value, in which case the breaks are exact.
+func (s *StreamingJenks) Breaks(nClasses int) (breaks []float64, gvfBound float64) {
+	values := make([]float64, len(s.bins))
+	counts := make([]int, len(s.bins))
+	weights := make([]float64, len(s.bins))
+	exact := true
+	withinBins := 0.0
+	for i, b := range s.bins {
+		values[i], counts[i], weights[i] = b.value(), b.count, float64(b.count)
+		exact = exact && b.min == b.max
+		withinBins += b.ssd()
+	}
+
+	c := HistogramNaturalClassification(values, counts, nClasses)
+	breaks = make([]float64, len(c.Classes))
+	for i, class := range c.Classes {
+		breaks[i] = s.bins[class.Start].min
+	}
+	if exact || len(c.Classes) == 0 {
+		return breaks, 0
+	}
+	return breaks, binnedGvfBound(c, values, weights, withinBins)
+}
+
+// binnedGvfBound bounds how much better the exact natural breaks of the values fit than the classification of the bins,
+// where withinBins is the sum of the squared deviations of the values in each bin from the bin's mean.
+//
+// As every class is made of whole bins, the sum of the squared deviations from the class means (SDCM) of the values
+// is the SDCM of the bins (each bin's values replaced by its mean) plus withinBins. The SDCM of any classification
+// is the squared distance from the values to the nearest values that are constant within each class, so replacing
+// the values by the bin means changes its square root by at most sqrt(withinBins). The exact SDCM is therefore at
+// least (sqrt(best) - sqrt(withinBins))^2, where best is the lowest SDCM of any classification of the bins.
+func binnedGvfBound(c *Classification, values, weights []float64, withinBins float64) float64 {
+	sdam := weightedSumOfSquareDeviations(values, weights) + withinBins
+	if sdam == 0 {
+		return 0
+	}
+	sdcm := withinBins
+	for _, class := range c.Classes {
+		sdcm += class.Variance * class.Weight
+	}
+
+	best := 0.0
+	for _, class := range weightedClassification(values, weights, len(c.Classes)).Classes {
+		best += class.Variance * class.Weight
+	}
+	lowest := math.Max(0, math.Sqrt(best)-math.Sqrt(withinBins))
+	return math.Max(0, sdcm-lowest*lowest) / sdam
+}
+
+// search returns the index of the first bin whose max is at least x.
+func (s *StreamingJenks) search(x float64) int {
+	return sort.Search(len(s.bins), func(i int) bool { return s.bins[i].max >= x })
+}
+
+// merge merges the pair of adjacent bins whose combined range is narrowest.
+func (s *StreamingJenks) merge() {
+	best, bestWidth := 0, math.Inf(+1)
+	for i := 0; i+1 < len(s.bins); i++ {
+		if width := s.bins[i+1].max - s.bins[i].min; width < bestWidth {
+			best, bestWidth = i, width
+		}
+	}
+
+	left, right := &s.bins[best], s.bins[best+1]
+	left.max = right.max
+	left.count += right.count
+	left.sum += right.sum
+	left.sumSquares += right.sumSquares
+	s.bins = append(s.bins[:best+1], s.bins[best+2:]...)
+}
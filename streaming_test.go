@@ -0,0 +1,78 @@
+package jenks
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestStreamingJenks_Exact(t *testing.T) {
+	s := NewStreamingJenks(len(benchmarkData))
+	for _, v := range benchmarkData {
+		s.Add(v)
+	}
+	s.Add(math.NaN())
+
+	if s.Len() != len(benchmarkData) {
+		t.Errorf("Len() = %v, want %v", s.Len(), len(benchmarkData))
+	}
+	for _, nClasses := range []int{1, 2, 5, 8} {
+		breaks, gvfBound := s.Breaks(nClasses)
+		if want := NaturalBreaks(benchmarkData, nClasses); !reflect.DeepEqual(breaks, want) {
+			t.Errorf("Breaks(%d) = %v, want %v", nClasses, breaks, want)
+		}
+		if gvfBound != 0 {
+			t.Errorf("Breaks(%d) GVF bound = %v, want 0", nClasses, gvfBound)
+		}
+	}
+}
+
+func TestStreamingJenks_Remove(t *testing.T) {
+	s := NewStreamingJenks(200)
+	for _, v := range benchmarkData {
+		s.Add(v)
+	}
+	extra := []float64{0, 1, 2, 150, 150, 72.0}
+	for _, v := range extra {
+		s.Add(v)
+	}
+	for _, v := range extra {
+		if !s.Remove(v) {
+			t.Errorf("Remove(%v) = false, want true", v)
+		}
+	}
+	if s.Remove(1000) {
+		t.Error("Remove(1000) = true, want false")
+	}
+
+	breaks, gvfBound := s.Breaks(5)
+	if want := NaturalBreaks(benchmarkData, 5); !reflect.DeepEqual(breaks, want) {
+		t.Errorf("Breaks(5) = %v, want %v", breaks, want)
+	}
+	if gvfBound != 0 {
+		t.Errorf("Breaks(5) GVF bound = %v, want 0", gvfBound)
+	}
+}
+
+func TestStreamingJenks_Approximate(t *testing.T) {
+	s := NewStreamingJenks(64)
+	for _, v := range largeBenchmarkData {
+		s.Add(v)
+	}
+	if len(s.bins) > 64 {
+		t.Errorf("the summary has %d bins, want at most 64", len(s.bins))
+	}
+
+	breaks, gvfBound := s.Breaks(5)
+	if gvfBound <= 0 {
+		t.Errorf("Breaks(5) GVF bound = %v, want more than 0", gvfBound)
+	}
+	want := NaturalBreaks(largeBenchmarkData, 5)
+	gvf, wantGvf := GVF(breaks, largeBenchmarkData), GVF(want, largeBenchmarkData)
+	if wantGvf-gvf > gvfBound {
+		t.Errorf("GVF of Breaks(5) = %v, want within %v of %v", gvf, gvfBound, wantGvf)
+	}
+	if wantGvf-gvf > 0.001 || gvfBound > 0.05 {
+		t.Errorf("GVF of Breaks(5) = %v (bound %v), want close to %v", gvf, gvfBound, wantGvf)
+	}
+}
@@ -0,0 +1,168 @@
+package jenks
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+)
+
+// SVGOptions configures the SVG written by Classification.WriteSVG. The zero value gives sensible defaults.
+type SVGOptions struct {
+	// Width and Height are the size of the image, in pixels (including the legend). The defaults are 640 and 400.
+	// The height is increased if needed to fit the legend, with a histogram at least 100 pixels high.
+	Width, Height int
+	// Bins is the number of bars in the histogram. The default is 50.
+	Bins int
+	// Palette colours the classes. The default is YlOrRd.
+	Palette Palette
+	// Title is shown above the histogram, along with the goodness of variance fit.
+	Title string
+}
+
+const (
+	svgMargin       = 40.0
+	svgLegendRow    = 18.0
+	svgFontSize     = 11.0
+	svgSwatchSize   = 12.0
+	svgBreakOverrun = 6.0
+	svgMinChart     = 100.0
+)
+
+// WriteSVG draws a histogram of the classified data as an SVG image, with each bar coloured by class
+// (a bar holding values from several classes is stacked), a vertical line at each break, the goodness of variance fit,
+// and a legend showing the range and size of each class. It uses only the standard library, so can be used from
+// tests and tools to review a classification. It returns ErrTooManyClasses if the palette is qualitative,
+// with fewer colours than there are classes.
+func (c *Classification) WriteSVG(w io.Writer, opts SVGOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = 640
+	}
+	if opts.Height <= 0 {
+		opts.Height = 400
+	}
+	if opts.Bins <= 0 {
+		opts.Bins = 50
+	}
+	if len(opts.Palette.Colors) == 0 {
+		opts.Palette = YlOrRd
+	}
+	legendHeight := 0.0
+	if len(c.Classes) > 0 {
+		legendHeight = svgLegendRow * float64(len(c.Classes)+1)
+	}
+	if minHeight := int(math.Ceil(2*svgMargin + svgMinChart + legendHeight)); opts.Height < minHeight {
+		opts.Height = minHeight
+	}
+
+	var b bytes.Buffer
+	width, height := float64(opts.Width), float64(opts.Height)
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="%g">`+"\n",
+		opts.Width, opts.Height, opts.Width, opts.Height, svgFontSize)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+
+	title := fmt.Sprintf("GVF %.4f", c.GVF)
+	if opts.Title != "" {
+		title = opts.Title + " – " + title
+	}
+	fmt.Fprintf(&b, `<text x="%g" y="%g" font-size="%g">%s</text>`+"\n", svgMargin, svgMargin/2, svgFontSize+3, html.EscapeString(title))
+
+	if len(c.Classes) > 0 {
+		colors, err := opts.Palette.ClassColors(len(c.Classes))
+		if err != nil {
+			return err
+		}
+		chart := svgRect{x: svgMargin, y: svgMargin, width: width - 2*svgMargin, height: height - 2*svgMargin - legendHeight}
+		c.writeHistogram(&b, chart, opts.Bins, colors)
+		c.writeLegend(&b, svgMargin, height-legendHeight, colors)
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// svgRect is the area of the image that the histogram is drawn in.
+type svgRect struct {
+	x, y, width, height float64
+}
+
+// writeHistogram draws the bars of the histogram, the axes and a line at each break.
+func (c *Classification) writeHistogram(b *bytes.Buffer, chart svgRect, bins int, colors []color.RGBA) {
+	// the range covers the data and every break - the breaks given to BreaksClassification needn't be within the data
+	min, max := c.Classes[0].Lower, c.Classes[len(c.Classes)-1].Lower
+	if len(c.Data) > 0 {
+		min, max = math.Min(min, c.Data[0]), math.Max(max, c.Data[len(c.Data)-1])
+	}
+	upper := max
+	if max == min {
+		// all the values are in a single bar
+		max = min + 1
+		bins = 1
+	}
+	binWidth := (max - min) / float64(bins)
+
+	// counts[bin][class] is the (weighted) number of values of the class in the bin
+	counts := make([][]float64, bins)
+	for i := range counts {
+		counts[i] = make([]float64, len(c.Classes))
+	}
+	tallest := 0.0
+	for class, cl := range c.Classes {
+		for i := cl.Start; i < cl.End; i++ {
+			bin := int(math.Min(float64(bins-1), math.Floor((c.Data[i]-min)/binWidth)))
+			counts[bin][class] += c.weight(i)
+		}
+	}
+	for _, bin := range counts {
+		total := 0.0
+		for _, count := range bin {
+			total += count
+		}
+		tallest = math.Max(tallest, total)
+	}
+
+	x := func(v float64) float64 { return chart.x + (v-min)/(max-min)*chart.width }
+	barWidth := chart.width / float64(bins)
+	for i, bin := range counts {
+		top := chart.y + chart.height
+		for class, count := range bin {
+			if count == 0 {
+				continue
+			}
+			h := count / tallest * chart.height
+			top -= h
+			fmt.Fprintf(b, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s" stroke="#666666" stroke-width="0.5"/>`+"\n",
+				chart.x+float64(i)*barWidth, top, barWidth, h, HexColor(colors[class]))
+		}
+	}
+
+	// axes, labelled with the range of the data and the tallest bar
+	bottom := chart.y + chart.height
+	fmt.Fprintf(b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="#000000"/>`+"\n", chart.x, bottom, chart.x+chart.width, bottom)
+	fmt.Fprintf(b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="#000000"/>`+"\n", chart.x, chart.y, chart.x, bottom)
+	fmt.Fprintf(b, `<text x="%.2f" y="%.2f" text-anchor="end">%g</text>`+"\n", chart.x-4, chart.y+svgFontSize, tallest)
+	fmt.Fprintf(b, `<text x="%.2f" y="%.2f" text-anchor="end">0</text>`+"\n", chart.x-4, bottom)
+	fmt.Fprintf(b, `<text x="%.2f" y="%.2f" text-anchor="end">%g</text>`+"\n", chart.x+chart.width, bottom+svgFontSize+4, upper)
+
+	// a line at every break, including the lowest
+	for _, cl := range c.Classes {
+		bx := x(cl.Lower)
+		fmt.Fprintf(b, `<line class="break" x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="#000000" stroke-dasharray="4 2"/>`+"\n",
+			bx, chart.y-svgBreakOverrun, bx, bottom+svgBreakOverrun)
+		fmt.Fprintf(b, `<text x="%.2f" y="%.2f" text-anchor="middle">%g</text>`+"\n", bx, bottom+svgFontSize+4, cl.Lower)
+	}
+}
+
+// writeLegend draws a swatch for each class, with its range and size.
+func (c *Classification) writeLegend(b *bytes.Buffer, x, y float64, colors []color.RGBA) {
+	labels := Labels(c.Breaks(), c.Classes[len(c.Classes)-1].Upper, LabelOptions{})
+	for i, cl := range c.Classes {
+		row := y + svgLegendRow*float64(i+1)
+		fmt.Fprintf(b, `<rect x="%.2f" y="%.2f" width="%g" height="%g" fill="%s" stroke="#000000" stroke-width="0.5"/>`+"\n",
+			x, row-svgSwatchSize, svgSwatchSize, svgSwatchSize, HexColor(colors[i]))
+		fmt.Fprintf(b, `<text x="%.2f" y="%.2f">%s (%g)</text>`+"\n", x+svgSwatchSize+6, row-2, html.EscapeString(labels[i]), cl.Weight)
+	}
+}
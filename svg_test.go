@@ -0,0 +1,93 @@
+package jenks
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestClassification_WriteSVG(t *testing.T) {
+	c := NaturalClassification(benchmarkData, 5)
+	var b bytes.Buffer
+	if err := c.WriteSVG(&b, SVGOptions{Title: "Benchmark <data>", Palette: Blues}); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+	svg := b.String()
+
+	// the SVG is well formed XML
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("WriteSVG() wrote invalid XML: %v\n%s", err, svg)
+		}
+	}
+
+	if got := strings.Count(svg, `class="break"`); got != 5 {
+		t.Errorf("WriteSVG() drew %d breaks, want 5", got)
+	}
+	for _, want := range []string{"Benchmark &lt;data&gt; – GVF 0.", "#eff3ff", "#08519c", "28.9 – 48.2 (8)"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("WriteSVG() doesn't contain %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestClassification_WriteSVG_Empty(t *testing.T) {
+	var b bytes.Buffer
+	if err := NaturalClassification([]float64{}, 5).WriteSVG(&b, SVGOptions{}); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "<svg") || !strings.HasSuffix(b.String(), "</svg>\n") {
+		t.Errorf("WriteSVG() = %v, want an empty SVG", b.String())
+	}
+
+	// a single value
+	b.Reset()
+	if err := NaturalClassification([]float64{3, 3}, 2).WriteSVG(&b, SVGOptions{}); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+	if strings.Contains(b.String(), "NaN") {
+		t.Errorf("WriteSVG() = %v, contains NaN", b.String())
+	}
+}
+
+func TestClassification_WriteSVG_Breaks(t *testing.T) {
+	data := []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 21.1, 22.1, 23.1, 27.1, 28.1, 29.1}
+	for _, breaks := range [][]float64{{5, 20}, {5, 20, 40}, nil} {
+		var b bytes.Buffer
+		if err := BreaksClassification(breaks, data).WriteSVG(&b, SVGOptions{}); err != nil {
+			t.Fatalf("WriteSVG() error = %v", err)
+		}
+		svg := b.String()
+		if want := len(breaks); want > 0 && strings.Count(svg, `class="break"`) != want {
+			t.Errorf("WriteSVG() of %v drew %d breaks, want %d", breaks, strings.Count(svg, `class="break"`), want)
+		}
+		if strings.Contains(svg, "NaN") || strings.Contains(svg, `x="-`) {
+			t.Errorf("WriteSVG() of %v drew outside the image:\n%s", breaks, svg)
+		}
+	}
+
+	// many classes, with a legend taller than the default height
+	var b bytes.Buffer
+	if err := NaturalClassification(largeBenchmarkData, 20).WriteSVG(&b, SVGOptions{}); err != nil {
+		t.Fatalf("WriteSVG() error = %v", err)
+	}
+	if svg := b.String(); strings.Contains(svg, `height="-`) || strings.Contains(svg, `y="-`) {
+		t.Errorf("WriteSVG() of 20 classes drew outside the image:\n%s", svg)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("failed") }
+
+func TestClassification_WriteSVG_Error(t *testing.T) {
+	if err := NaturalClassification(benchmarkData, 5).WriteSVG(failingWriter{}, SVGOptions{}); err == nil {
+		t.Error("WriteSVG() error = nil, want the writer's error")
+	}
+}
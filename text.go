@@ -0,0 +1,78 @@
+package jenks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextOptions configures the histogram written by Classification.WriteText. The zero value gives sensible defaults.
+type TextOptions struct {
+	// Width is the length of the longest bar, in characters. The default is 40.
+	Width int
+	// ASCII, if set, draws the bars with '#' characters (and the ranges with '-'), rather than Unicode block elements,
+	// which show the length of each bar to an eighth of a character.
+	ASCII bool
+}
+
+// eighths are the Unicode block elements for 1/8 to 7/8 of a character.
+var eighths = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// WriteText draws the classification as a text histogram for logs and the command line: one bar per class,
+// proportional to the number of values in it, labelled with the range, size and mean of the class,
+// followed by the goodness of variance fit.
+func (c *Classification) WriteText(w io.Writer, opts TextOptions) error {
+	if opts.Width <= 0 {
+		opts.Width = 40
+	}
+	labelOpts := LabelOptions{}
+	if opts.ASCII {
+		labelOpts.Separator = " - "
+	}
+
+	var b bytes.Buffer
+	if len(c.Classes) > 0 {
+		labels := Labels(c.Breaks(), c.Classes[len(c.Classes)-1].Upper, labelOpts)
+		labelWidth, largest := 0, 0.0
+		for i, class := range c.Classes {
+			if n := utf8.RuneCountInString(labels[i]); n > labelWidth {
+				labelWidth = n
+			}
+			largest = math.Max(largest, class.Weight)
+		}
+
+		for i, class := range c.Classes {
+			length := 0.0
+			if largest > 0 {
+				length = class.Weight / largest * float64(opts.Width)
+			}
+			bar := textBar(length, opts.ASCII)
+			fmt.Fprintf(&b, "%s%s  %s%s  %g (mean %.6g)\n",
+				labels[i], strings.Repeat(" ", labelWidth-utf8.RuneCountInString(labels[i])),
+				bar, strings.Repeat(" ", opts.Width-utf8.RuneCountInString(bar)),
+				class.Weight, class.Mean)
+		}
+	}
+	fmt.Fprintf(&b, "GVF %.4f\n", c.GVF)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// textBar returns a bar of the given length, in characters.
+func textBar(length float64, ascii bool) string {
+	if ascii {
+		return strings.Repeat("#", int(math.Round(length)))
+	}
+	whole := int(length)
+	bar := strings.Repeat("█", whole)
+	if part := int(math.Round((length - float64(whole)) * 8)); part == 8 {
+		bar += "█"
+	} else if part > 0 {
+		bar += string(eighths[part-1])
+	}
+	return bar
+}
@@ -0,0 +1,66 @@
+package jenks
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestClassification_WriteText(t *testing.T) {
+	data := []float64{1.1, 2.1, 3.1, 12.1, 13.1, 14.1, 15.1, 16.1, 17.1, 21.1, 22.1, 23.1, 24.1, 27.1, 28.1, 29.1}
+	c := NaturalClassification(data, 4)
+
+	tests := []struct {
+		name string
+		opts TextOptions
+		want string
+	}{
+		{name: "unicode",
+			opts: TextOptions{Width: 10},
+			want: "" +
+				"1.1 – 12.1   █████       3 (mean 2.1)\n" +
+				"12.1 – 21.1  ██████████  6 (mean 14.6)\n" +
+				"21.1 – 27.1  ██████▋     4 (mean 22.6)\n" +
+				"27.1 – 29.1  █████       3 (mean 28.1)\n" +
+				"GVF 0.9783\n"},
+		{name: "ascii",
+			opts: TextOptions{Width: 10, ASCII: true},
+			want: "" +
+				"1.1 - 12.1   #####       3 (mean 2.1)\n" +
+				"12.1 - 21.1  ##########  6 (mean 14.6)\n" +
+				"21.1 - 27.1  #######     4 (mean 22.6)\n" +
+				"27.1 - 29.1  #####       3 (mean 28.1)\n" +
+				"GVF 0.9783\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := c.WriteText(&b, tt.opts); err != nil {
+				t.Fatalf("WriteText() error = %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("WriteText() =\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestClassification_WriteText_Empty(t *testing.T) {
+	var b bytes.Buffer
+	if err := NaturalClassification([]float64{}, 4).WriteText(&b, TextOptions{}); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if b.String() != "GVF 0.0000\n" {
+		t.Errorf("WriteText() = %q, want %q", b.String(), "GVF 0.0000\n")
+	}
+	if err := NaturalClassification(benchmarkData, 4).WriteText(failingWriter{}, TextOptions{}); err == nil {
+		t.Error("WriteText() error = nil, want the writer's error")
+	}
+}
+
+func TestTextBar(t *testing.T) {
+	for length, want := range map[float64]string{0: "", 0.05: "", 0.125: "▏", 2.5: "██▌", 2.99: "███"} {
+		if got := textBar(length, false); got != want {
+			t.Errorf("textBar(%v) = %q, want %q", length, got, want)
+		}
+	}
+}
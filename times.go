@@ -0,0 +1,119 @@
+package jenks
+
+import (
+	"sort"
+	"time"
+)
+
+// DurationBreaks returns the best nClasses natural breaks in the durations - e.g. request latencies.
+// Like NaturalBreaks, each break is the lowest duration in its class.
+func DurationBreaks(data []time.Duration, nClasses int) []time.Duration {
+	return NaturalBreaksOf(data, nClasses)
+}
+
+// TimeBreaks returns the best nClasses natural breaks in the times - e.g. event timestamps.
+// Like NaturalBreaks, each break is the earliest time in its class. The times must span less than about 292 years.
+func TimeBreaks(data []time.Time, nClasses int) []time.Time {
+	sorted := append(make([]time.Time, 0, len(data)), data...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	if len(sorted) == 0 {
+		return []time.Time{}
+	}
+
+	// the times are classified as offsets from the earliest, which (unlike Unix times)
+	// are small enough to be classified without losing precision
+	offsets := make([]float64, len(sorted))
+	for i, t := range sorted {
+		offsets[i] = float64(t.Sub(sorted[0]))
+	}
+
+	c := naturalClassification(offsets, nClasses, Options{})
+	breaks := make([]time.Time, len(c.Classes))
+	for i, class := range c.Classes {
+		breaks[i] = sorted[class.Start]
+	}
+	return breaks
+}
+
+// durationSteps are the units that durations are rounded to, from the coarsest to the finest.
+var durationSteps = []time.Duration{
+	24 * time.Hour, 12 * time.Hour, 6 * time.Hour, 3 * time.Hour, time.Hour,
+	30 * time.Minute, 15 * time.Minute, 10 * time.Minute, 5 * time.Minute, time.Minute,
+	30 * time.Second, 15 * time.Second, 10 * time.Second, 5 * time.Second, time.Second,
+	500 * time.Millisecond, 100 * time.Millisecond, 50 * time.Millisecond, 10 * time.Millisecond, 5 * time.Millisecond, time.Millisecond,
+	500 * time.Microsecond, 100 * time.Microsecond, 50 * time.Microsecond, 10 * time.Microsecond, 5 * time.Microsecond, time.Microsecond,
+}
+
+// RoundDurations is like Round, for durations: each break is rounded down to a whole number of the coarsest unit possible
+// (days, hours, minutes, seconds, milliseconds, microseconds, or a round fraction of them),
+// without changing the membership of any class.
+func RoundDurations(breaks []time.Duration, data []time.Duration) []time.Duration {
+	sorted, _ := sortNumbers(data)
+	rounded := make([]time.Duration, len(breaks))
+	for breakIdx, b := range breaks {
+		// floor is the value that this break must remain above (as in Round)
+		dataIdx := firstAtLeast(sorted, b)
+		var floor time.Duration
+		if dataIdx == 0 {
+			next := sorted[len(sorted)-1]
+			if breakIdx+1 < len(breaks) {
+				next = breaks[breakIdx+1]
+			}
+			floor = sorted[0] - (next - b)
+		} else {
+			floor = sorted[dataIdx-1]
+		}
+
+		rounded[breakIdx] = b
+		for _, step := range durationSteps {
+			if r := truncateDuration(b, step); r > floor {
+				rounded[breakIdx] = r
+				break
+			}
+		}
+	}
+	return rounded
+}
+
+// RoundTimes is like Round, for times: each break is rounded down to the start of the day, or to a whole number of
+// the coarsest unit possible within the day (as RoundDurations), in the break's location, without changing
+// the membership of any class.
+func RoundTimes(breaks []time.Time, data []time.Time) []time.Time {
+	sorted := append(make([]time.Time, 0, len(data)), data...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	rounded := make([]time.Time, len(breaks))
+	for breakIdx, b := range breaks {
+		// floor is the time that this break must remain after (as in Round)
+		dataIdx := sort.Search(len(sorted), func(i int) bool { return !sorted[i].Before(b) })
+		var floor time.Time
+		if dataIdx == 0 {
+			next := sorted[len(sorted)-1]
+			if breakIdx+1 < len(breaks) {
+				next = breaks[breakIdx+1]
+			}
+			floor = sorted[0].Add(-next.Sub(b))
+		} else {
+			floor = sorted[dataIdx-1]
+		}
+
+		rounded[breakIdx] = b
+		midnight := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, b.Location())
+		for _, step := range durationSteps {
+			if r := midnight.Add(truncateDuration(b.Sub(midnight), step)); r.After(floor) {
+				rounded[breakIdx] = r
+				break
+			}
+		}
+	}
+	return rounded
+}
+
+// truncateDuration rounds d down (towards minus infinity) to a multiple of step.
+func truncateDuration(d, step time.Duration) time.Duration {
+	r := d / step * step
+	if r > d {
+		r -= step
+	}
+	return r
+}
@@ -0,0 +1,73 @@
+package jenks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var latencies = []time.Duration{
+	12 * time.Millisecond, 15 * time.Millisecond, 14 * time.Millisecond, 18 * time.Millisecond,
+	230 * time.Millisecond, 245 * time.Millisecond, 251 * time.Millisecond,
+	1300 * time.Millisecond, 1420 * time.Millisecond, 1650 * time.Millisecond,
+}
+
+func TestDurationBreaks(t *testing.T) {
+	want := []time.Duration{12 * time.Millisecond, 230 * time.Millisecond, 1300 * time.Millisecond}
+	if got := DurationBreaks(latencies, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("DurationBreaks() = %v, want %v", got, want)
+	}
+}
+
+func TestRoundDurations(t *testing.T) {
+	breaks := []time.Duration{12 * time.Millisecond, 230 * time.Millisecond, 1300 * time.Millisecond}
+	want := []time.Duration{0, 200 * time.Millisecond, time.Second}
+	if got := RoundDurations(breaks, latencies); !reflect.DeepEqual(got, want) {
+		t.Errorf("RoundDurations() = %v, want %v", got, want)
+	}
+
+	// a break that can't be rounded at all is unchanged
+	data := []time.Duration{time.Second, time.Second + 1}
+	if got := RoundDurations([]time.Duration{time.Second, time.Second + 1}, data); got[1] != time.Second+1 {
+		t.Errorf("RoundDurations() = %v, want %v", got, []time.Duration{0, time.Second + 1})
+	}
+}
+
+func TestTimeBreaks(t *testing.T) {
+	start := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	data := []time.Time{}
+	for _, d := range []time.Duration{0, time.Minute, 3 * time.Minute, 2 * time.Hour, 2*time.Hour + 5*time.Minute, 30 * time.Hour, 31 * time.Hour} {
+		data = append(data, start.Add(d))
+	}
+
+	breaks := TimeBreaks(data, 3)
+	want := []time.Time{data[0], data[3], data[5]}
+	if !reflect.DeepEqual(breaks, want) {
+		t.Errorf("TimeBreaks() = %v, want %v", breaks, want)
+	}
+
+	rounded := RoundTimes(breaks, data)
+	want = []time.Time{
+		time.Date(2023, 11, 14, 21, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 16, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(rounded, want) {
+		t.Errorf("RoundTimes() = %v, want %v", rounded, want)
+	}
+}
+
+func TestRoundTimes_Location(t *testing.T) {
+	india := time.FixedZone("IST", 5*60*60+30*60)
+	data := []time.Time{
+		time.Date(2024, 3, 1, 9, 10, 0, 0, india),
+		time.Date(2024, 3, 1, 9, 40, 0, 0, india),
+		time.Date(2024, 3, 1, 10, 20, 0, 0, india),
+		time.Date(2024, 3, 1, 10, 50, 0, 0, india),
+	}
+	breaks := []time.Time{data[0], data[2]}
+	want := []time.Time{time.Date(2024, 3, 1, 9, 0, 0, 0, india), time.Date(2024, 3, 1, 10, 0, 0, 0, india)}
+	if got := RoundTimes(breaks, data); !reflect.DeepEqual(got, want) {
+		t.Errorf("RoundTimes() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package jenks
+
+import (
+	"math"
+	"sort"
+)
+
+// WeightedNaturalBreaks returns the best nClasses natural breaks in the values, where each value has the corresponding weight
+// - e.g. the population of a census tract. The breaks minimise the same variance as if each value were repeated
+// 'weight' times, without the memory cost of doing so. Unlike NaturalBreaks, the largest value is included in the
+// optimisation, so that only the relative weights matter - use HistogramNaturalBreaks for the same breaks as NaturalBreaks.
+// Values with a weight of zero (or less) are ignored; values and weights must have the same length.
+func WeightedNaturalBreaks(values []float64, weights []float64, nClasses int) []float64 {
+	return WeightedNaturalClassification(values, weights, nClasses).Breaks()
+}
+
+// WeightedNaturalBreaksE is like WeightedNaturalBreaks, but returns an error instead of panicking or returning meaningless
+// breaks when the data is empty, contains NaN or infinite values, the weights are invalid or nClasses is less than 1.
+// Like NaturalBreaksE, it switches to the linear memory method rather than exceeding MaxMatrixSize.
+func WeightedNaturalBreaksE(values []float64, weights []float64, nClasses int) ([]float64, error) {
+	if err := validateData(values); err != nil {
+		return nil, err
+	}
+	if err := validateWeights(values, weights); err != nil {
+		return nil, err
+	}
+	if nClasses < 1 {
+		return nil, ErrInvalidClassCount
+	}
+
+	data, weights := mergeWeights(values, weights)
+	if len(data) == 0 {
+		// every value had a weight of zero
+		return nil, ErrEmptyData
+	}
+	return weightedClassification(data, weights, nClasses).Breaks(), nil
+}
+
+// WeightedNaturalClassification classifies the weighted values into the best nClasses natural breaks (see WeightedNaturalBreaks),
+// returning the statistics of each class. The Data of the result holds each distinct value once,
+// with Weights holding the total weight of that value.
+func WeightedNaturalClassification(values []float64, weights []float64, nClasses int) *Classification {
+	data, weights := mergeWeights(values, weights)
+	if len(data) == 0 {
+		return &Classification{Data: data, Weights: weights}
+	}
+	return weightedClassification(data, weights, nClasses)
+}
+
+// weightedClassification classifies the distinct, sorted (non-empty) data with the corresponding (positive) weights.
+func weightedClassification(data []float64, weights []float64, nClasses int) *Classification {
+	if nClasses >= len(data) {
+		return uniqueClassification(data, weights)
+	}
+	// unlike forEachBreak, the walk starts from the last data point, so that its weight is taken into account
+	return newClassification(data, weights, weightedBoundaries(data, weights, len(data), nClasses))
+}
+
+// uniqueClassification puts each of the distinct, sorted data points in a class of its own.
+func uniqueClassification(data []float64, weights []float64) *Classification {
+	boundaries := make([]int, len(data))
+	for i := range data {
+		boundaries[i] = i
+	}
+	return newClassification(data, weights, boundaries)
+}
+
+// weightedBoundaries returns the index of the lowest data point in each of nClasses classes of the weighted data,
+// walking back from the class ending at data point 'last' (counting from 1).
+func weightedBoundaries(data []float64, weights []float64, last, nClasses int) []int {
+	if !matrixFits(len(data), nClasses) {
+		return linearMemoryBoundaries(data, weights, last, nClasses, 1)
+	}
+
+	lowerClassLimits, _ := getWeightedMatrices(data, weights, nClasses)
+	boundaries := make([]int, nClasses)
+	forEachLimit(lowerClassLimits, last, nClasses, nClasses, func(class, boundary int) {
+		boundaries[class-1] = boundary
+	})
+	return boundaries
+}
+
+// mergeWeights returns the distinct values in ascending order, along with the total weight of each.
+// Values with a weight of zero (or less) are dropped.
+func mergeWeights(values []float64, weights []float64) ([]float64, []float64) {
+	if len(values) != len(weights) {
+		panic("jenks: values and weights must have the same length")
+	}
+
+	sorted := weightedValues{values: make([]float64, 0, len(values)), weights: make([]float64, 0, len(weights))}
+	for i, w := range weights {
+		if w > 0 {
+			sorted.values = append(sorted.values, values[i])
+			sorted.weights = append(sorted.weights, w)
+		}
+	}
+	sort.Sort(sorted)
+
+	merged := weightedValues{values: make([]float64, 0, sorted.Len()), weights: make([]float64, 0, sorted.Len())}
+	for i, v := range sorted.values {
+		if n := len(merged.values); n > 0 && merged.values[n-1] == v {
+			merged.weights[n-1] += sorted.weights[i]
+		} else {
+			merged.values = append(merged.values, v)
+			merged.weights = append(merged.weights, sorted.weights[i])
+		}
+	}
+	return merged.values, merged.weights
+}
+
+// validateWeights checks that there is one finite, non-negative weight for each value.
+func validateWeights(values []float64, weights []float64) error {
+	if len(values) != len(weights) {
+		return ErrInvalidWeights
+	}
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return ErrInvalidWeights
+		}
+	}
+	return nil
+}
+
+// weightedValues sorts values along with their weights.
+type weightedValues struct {
+	values  []float64
+	weights []float64
+}
+
+func (w weightedValues) Len() int           { return len(w.values) }
+func (w weightedValues) Less(i, j int) bool { return w.values[i] < w.values[j] }
+func (w weightedValues) Swap(i, j int) {
+	w.values[i], w.values[j] = w.values[j], w.values[i]
+	w.weights[i], w.weights[j] = w.weights[j], w.weights[i]
+}
+
+func weightedMean(data []float64, weights []float64) float64 {
+	sum, total := 0.0, 0.0
+	for i, v := range data {
+		sum += weights[i] * v
+		total += weights[i]
+	}
+	if total == 0 {
+		return 0.0
+	}
+	return sum / total
+}
+
+func weightedSumOfSquareDeviations(data []float64, weights []float64) float64 {
+	mean := weightedMean(data, weights)
+	sum := 0.0
+	for i, v := range data {
+		diff := v - mean
+		sum += weights[i] * diff * diff
+	}
+	return sum
+}
@@ -0,0 +1,157 @@
+package jenks
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWeightedNaturalBreaks(t *testing.T) {
+	type args struct {
+		values   []float64
+		weights  []float64
+		nClasses int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []float64
+	}{
+		{name: "unit weights",
+			args: args{nClasses: 4, values: []float64{1, 2, 3, 12, 13, 14, 21, 22, 23, 27, 28, 29}, weights: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+			want: []float64{1, 12, 21, 27}},
+		{name: "unsorted values",
+			args: args{nClasses: 3, values: []float64{29, 1, 22, 13, 2, 28, 12, 3, 21, 27, 14, 23}, weights: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+			want: []float64{1, 12, 21}},
+		{name: "heavy weight pulls neighbouring values into its class",
+			args: args{nClasses: 2, values: []float64{1, 2, 3, 4, 5, 6}, weights: []float64{1, 1, 1, 1, 1, 100}},
+			want: []float64{1, 5}},
+		{name: "heavy weights split apart",
+			args: args{nClasses: 3, values: []float64{1, 2, 3, 4, 5, 6}, weights: []float64{1, 1, 50, 50, 1, 1}},
+			want: []float64{1, 3, 4}},
+		{name: "zero weights are ignored",
+			args: args{nClasses: 2, values: []float64{-100, 1, 2, 3, 12, 13, 14}, weights: []float64{0, 1, 1, 1, 1, 1, 1}},
+			want: []float64{1, 12}},
+		{name: "duplicate values are merged",
+			args: args{nClasses: 3, values: []float64{1, 1, 2, 2, 3, 3}, weights: []float64{1, 2, 3, 4, 5, 6}},
+			want: []float64{1, 2, 3}},
+		{name: "no values",
+			args: args{nClasses: 2, values: []float64{}, weights: []float64{}},
+			want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeightedNaturalBreaks(tt.args.values, tt.args.weights, tt.args.nClasses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WeightedNaturalBreaks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightedNaturalBreaksIsOptimal(t *testing.T) {
+	values := []float64{28.9, 40.7, 52.2, 55.8, 60.1, 64.7, 67.0, 71.2, 73.0, 76.3, 79.2, 83.8, 87.7, 99.2}
+	weights := []float64{1, 1, 2, 2, 2, 2, 2, 4, 4, 3, 3, 2, 2, 2.5}
+
+	for nClasses := 2; nClasses <= 6; nClasses++ {
+		got := WeightedNaturalClassification(values, weights, nClasses)
+		want := bruteForceGvf(values, weights, nClasses)
+		if math.Abs(got.GVF-want) > 1e-12 {
+			t.Errorf("WeightedNaturalClassification(%d).GVF = %v, want %v (breaks %v)", nClasses, got.GVF, want, got.Breaks())
+		}
+		total := 0.0
+		for _, c := range got.Classes {
+			total += c.Weight
+		}
+		if total != 32.5 {
+			t.Errorf("WeightedNaturalClassification(%d) total weight = %v, want 32.5", nClasses, total)
+		}
+	}
+}
+
+func TestWeightedNaturalBreaksScaledWeights(t *testing.T) {
+	values := []float64{1, 2, 3, 10, 11, 12, 30}
+	weights := []float64{.1, .1, .1, .1, .1, .1, .4}
+	scaled := make([]float64, len(weights))
+	for i, w := range weights {
+		scaled[i] = w * 1000
+	}
+
+	// (with 4 classes, {1, 2} and {2, 3} tie, so rounding may pick either)
+	for nClasses := 2; nClasses <= 3; nClasses++ {
+		want := WeightedNaturalBreaks(values, scaled, nClasses)
+		if got := WeightedNaturalBreaks(values, weights, nClasses); !reflect.DeepEqual(got, want) {
+			t.Errorf("WeightedNaturalBreaks(%d) = %v, want %v as for the scaled weights", nClasses, got, want)
+		}
+	}
+	if got, want := WeightedNaturalBreaks(values, weights, 3), []float64{1, 10, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WeightedNaturalBreaks(3) = %v, want %v", got, want)
+	}
+}
+
+func TestHistogramNaturalBreaksUnitCounts(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 100; trial++ {
+		values := make([]float64, 40)
+		counts := make([]int, len(values))
+		for i := range values {
+			values[i] = math.Round(random.ExpFloat64()*100) / 10
+			counts[i] = 1
+		}
+		nClasses := 2 + random.Intn(6)
+
+		want := NaturalBreaks(values, nClasses)
+		if got := HistogramNaturalBreaks(values, counts, nClasses); !reflect.DeepEqual(got, want) {
+			t.Errorf("HistogramNaturalBreaks(%v, %d) = %v, want %v", values, nClasses, got, want)
+		}
+	}
+}
+
+// bruteForceGvf returns the best goodness of variance fit of any partition of the (sorted, distinct) values into nClasses.
+func bruteForceGvf(values []float64, weights []float64, nClasses int) float64 {
+	best := 0.0
+	var try func(boundaries []int)
+	try = func(boundaries []int) {
+		if len(boundaries) == nClasses {
+			if gvf := newClassification(values, weights, boundaries).GVF; gvf > best {
+				best = gvf
+			}
+			return
+		}
+		for b := boundaries[len(boundaries)-1] + 1; b < len(values); b++ {
+			try(append(boundaries[:len(boundaries):len(boundaries)], b))
+		}
+	}
+	try([]int{0})
+	return best
+}
+
+func TestWeightedNaturalBreaksE(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		weights []float64
+		want    []float64
+		wantErr error
+	}{
+		{name: "valid", values: []float64{1, 2, 10, 11}, weights: []float64{1, 2, 3, 4}, want: []float64{1, 10}},
+		{name: "empty", values: []float64{}, weights: []float64{}, wantErr: ErrEmptyData},
+		{name: "all zero weights", values: []float64{1, 2}, weights: []float64{0, 0}, wantErr: ErrEmptyData},
+		{name: "NaN value", values: []float64{1, math.NaN()}, weights: []float64{1, 1}, wantErr: ErrNaN},
+		{name: "mismatched lengths", values: []float64{1, 2}, weights: []float64{1}, wantErr: ErrInvalidWeights},
+		{name: "negative weight", values: []float64{1, 2}, weights: []float64{1, -1}, wantErr: ErrInvalidWeights},
+		{name: "NaN weight", values: []float64{1, 2}, weights: []float64{1, math.NaN()}, wantErr: ErrInvalidWeights},
+		{name: "infinite weight", values: []float64{1, 2}, weights: []float64{math.Inf(1), 1}, wantErr: ErrInvalidWeights},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WeightedNaturalBreaksE(tt.values, tt.weights, 2)
+			if err != tt.wantErr {
+				t.Fatalf("WeightedNaturalBreaksE() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WeightedNaturalBreaksE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}